@@ -3,7 +3,10 @@ package openapi
 import (
 	"encoding/json/jsontext"
 	"encoding/json/v2"
+	"fmt"
 	"iter"
+	"net/http"
+	"net/url"
 
 	"github.com/MarkRosemaker/errpath"
 	"github.com/MarkRosemaker/ordmap"
@@ -16,13 +19,17 @@ import (
 // To describe incoming requests from the API provider independent from another API call, use the `webhooks` field.
 //
 // Note that according to the specification, this object MAY be extended with Specification Extensions, but we do not support that in this implementation.
-// Note that we are not validating the [runtime expression] in this implementation.
+// The key is validated as a [runtime expression], see RuntimeExpression.Parse, and can be evaluated with Evaluate.
 //
 // [runtime expression]: https://spec.openapis.org/oas/v3.1.0#key-expression
 type Callback map[RuntimeExpression]*PathItemRef
 
 func (c Callback) Validate() error {
 	for expr, p := range c.ByIndex() {
+		if err := expr.Validate(); err != nil {
+			return &errpath.ErrKey{Key: string(expr), Err: err}
+		}
+
 		if err := p.Validate(); err != nil {
 			return &errpath.ErrKey{Key: string(expr), Err: err}
 		}
@@ -31,6 +38,62 @@ func (c Callback) Validate() error {
 	return nil
 }
 
+// CallbackTarget is a callback whose URL has been evaluated.
+type CallbackTarget struct {
+	// The name of the callback in the callbacks of the operation, if known.
+	Name string
+	// The runtime expression that the URL was evaluated from.
+	Expression RuntimeExpression
+	// The URL to send the callback requests to.
+	URL *url.URL
+	// The path item that describes the callback requests.
+	PathItem *PathItem
+}
+
+// Evaluate evaluates the runtime expressions of the callback for the request and response that triggered it
+// and returns the URLs along with their path items, in order. Unresolved path items are skipped.
+// It returns an error if an expression can't be evaluated or does not result in an absolute URL.
+func (c Callback) Evaluate(req *http.Request, res *http.Response) ([]*CallbackTarget, error) {
+	var targets []*CallbackTarget
+	for expr, p := range c.ByIndex() {
+		if p.Value == nil {
+			continue
+		}
+
+		u, err := evaluateCallbackURL(expr, req, res)
+		if err != nil {
+			return nil, &errpath.ErrKey{Key: string(expr), Err: err}
+		}
+
+		targets = append(targets, &CallbackTarget{Expression: expr, URL: u, PathItem: p.Value})
+	}
+
+	return targets, nil
+}
+
+func evaluateCallbackURL(expr RuntimeExpression, req *http.Request, res *http.Response) (*url.URL, error) {
+	v, err := expr.Evaluate(req, res)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a URL, got %T", v)
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.IsAbs() || u.Host == "" {
+		return nil, fmt.Errorf("callback URL %q is not absolute", s)
+	}
+
+	return u, nil
+}
+
 // ByIndex returns a sequence of key-value pairs ordered by index.
 func (c Callback) ByIndex() iter.Seq2[RuntimeExpression, *PathItemRef] {
 	return ordmap.ByIndex(c, getIndexRef[PathItem, *PathItem])
@@ -71,7 +134,7 @@ func (l *loader) collectCallback(c *Callback, ref ref) {
 }
 
 func (l *loader) resolveCallbackRef(c *CallbackRef) error {
-	return resolveRef(c, l.callbacks, l.resolveCallback)
+	return resolveRef(l, c, l.callbacks, l.resolveCallback)
 }
 
 func (l *loader) resolveCallback(c *Callback) error {
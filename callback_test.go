@@ -1,6 +1,10 @@
 package openapi_test
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/MarkRosemaker/openapi"
@@ -75,3 +79,88 @@ func TestCallback_Validate_Error(t *testing.T) {
 		t.Fatalf("expected %q, got %q", want, err.Error())
 	}
 }
+
+func TestCallbacks_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan string, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Method + " " + r.URL.String()
+	}))
+	defer receiver.Close()
+
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"info": {"title": "Subscriptions", "version": "1.0"},
+"paths": {"/subscribe": {"post": {
+	"responses": {"201": {"description": "Created"}},
+	"callbacks": {
+		"onEvent": {"{$request.body#/callbackUrl}/events?id={$response.body#/id}": {"post": {"responses": {"200": {"description": "OK"}}}}},
+		"audit": {"https://audit.example.com/{$method}": {"put": {"responses": {"200": {"description": "OK"}}}}}
+	}
+}}}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{"callbackUrl": "`+receiver.URL+`/hooks"}`))
+	res := &http.Response{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(`{"id": 42}`))}
+
+	targets, err := doc.Paths["/subscribe"].Post.Callbacks.Evaluate(req, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("want 2 targets, got %d", len(targets))
+	}
+
+	if got, want := targets[0].Name+" "+targets[0].URL.String(), "audit https://audit.example.com/POST"; got != want {
+		t.Fatalf("want: %s, got: %s", want, got)
+	}
+
+	target := targets[1]
+	if got, want := target.URL.String(), receiver.URL+"/hooks/events?id=42"; target.Name != "onEvent" || got != want {
+		t.Fatalf("want: onEvent %s, got: %s %s", want, target.Name, got)
+	}
+
+	for method := range target.PathItem.Operations {
+		cbReq, err := http.NewRequest(method, target.URL.String(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cbRes, err := receiver.Client().Do(cbReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cbRes.Body.Close()
+	}
+
+	if got, want := <-received, "POST /hooks/events?id=42"; got != want {
+		t.Fatalf("want: %s, got: %s", want, got)
+	}
+}
+
+func TestCallback_Evaluate_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		expr openapi.RuntimeExpression
+		err  string
+	}{
+		{"{$request.query.callbackUrl}", `["{$request.query.callbackUrl}"]: $request.query.callbackUrl: value not found`},
+		{"{$request.body#/port}", `["{$request.body#/port}"]: expected a URL, got openapi.Number`},
+		{"{$request.body#/path}/events", `["{$request.body#/path}/events"]: callback URL "/hooks/events" is not absolute`},
+	} {
+		t.Run(string(tc.expr), func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{"port": 8080, "path": "/hooks"}`))
+			cb := openapi.Callback{tc.expr: {Value: &openapi.PathItem{}}}
+
+			if _, err := cb.Evaluate(req, nil); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package openapi
 
-import "github.com/MarkRosemaker/errpath"
+import (
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/MarkRosemaker/errpath"
+)
 
 // Callbacks holds a set of reusable callbacks.
 type Callbacks map[string]Callback
@@ -15,3 +21,22 @@ func (cs Callbacks) Validate() error {
 
 	return nil
 }
+
+// Evaluate evaluates all callbacks for the request and response that triggered them, ordered by name, see Callback.Evaluate.
+func (cs Callbacks) Evaluate(req *http.Request, res *http.Response) ([]*CallbackTarget, error) {
+	var targets []*CallbackTarget
+	for _, name := range slices.Sorted(maps.Keys(cs)) {
+		ts, err := cs[name].Evaluate(req, res)
+		if err != nil {
+			return nil, &errpath.ErrKey{Key: name, Err: err}
+		}
+
+		for _, t := range ts {
+			t.Name = name
+		}
+
+		targets = append(targets, ts...)
+	}
+
+	return targets, nil
+}
@@ -1,19 +1,43 @@
 package openapi
 
 import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/MarkRosemaker/errpath"
 )
 
 // Format defines additional formats to provide fine detail for primitive data types.
+//
+// Formats are open-ended: the formats of the [OpenAPI Format Registry] are known, others can be added with RegisterFormat.
+//
+// [OpenAPI Format Registry]: https://spec.openapis.org/registry/format/
 type Format string
 
 const (
+	// FormatInt8 represents a signed 8 bits integer.
+	FormatInt8 Format = "int8"
+	// FormatInt16 represents a signed 16 bits integer.
+	FormatInt16 Format = "int16"
 	// FormatInt32 represents a signed 32 bits integer.
 	FormatInt32 Format = "int32"
 	// FormatInt64 represents a signed 64 bits integer.
 	FormatInt64 Format = "int64"
+	// FormatUint8 represents an unsigned 8 bits integer.
+	FormatUint8 Format = "uint8"
 	// FormatUint represents an unsigned integer.
 	FormatUint Format = "uint"
 	// FormatUint32 represents an unsigned 32 bits integer.
@@ -24,54 +48,379 @@ const (
 	FormatFloat Format = "float"
 	// FormatDouble represents a double number.
 	FormatDouble Format = "double"
+	// FormatDoubleInt represents an integer that can be stored in a double without loss of precision.
+	FormatDoubleInt Format = "double-int"
+	// FormatDecimal represents a fixed point decimal number of unspecified precision and range.
+	FormatDecimal Format = "decimal"
+	// FormatDecimal128 represents a decimal floating-point number with 34 significant decimal digits.
+	FormatDecimal128 Format = "decimal128"
+	// FormatUnixTime represents the number of seconds since the Unix epoch.
+	FormatUnixTime Format = "unixtime"
 	// FormatByte represents a byte.
 	FormatByte Format = "byte"
+	// FormatBase64URL represents binary data encoded as a URL-safe base64 string.
+	FormatBase64URL Format = "base64url"
 	// FormatBinary represents a binary.
 	FormatBinary Format = "binary"
+	// FormatChar represents a single character.
+	FormatChar Format = "char"
+	// FormatCommonMark represents CommonMark-formatted text.
+	FormatCommonMark Format = "commonmark"
+	// FormatHTML represents HTML-formatted text.
+	FormatHTML Format = "html"
 	// FormatDate represents a date.
 	FormatDate Format = "date"
 	// FormatDateTime represents a date-time.
 	FormatDateTime Format = "date-time"
+	// FormatDateTimeLocal represents a date-time without time zone.
+	FormatDateTimeLocal Format = "date-time-local"
+	// FormatTime represents a time of day.
+	FormatTime Format = "time"
+	// FormatTimeLocal represents a time of day without time zone.
+	FormatTimeLocal Format = "time-local"
+	// FormatHTTPDate represents a date as used in HTTP headers.
+	FormatHTTPDate Format = "http-date"
 	// FormatDuration represents a duration.
 	FormatDuration Format = "duration"
 	// FormatEmail represents an email.
 	FormatEmail Format = "email"
+	// FormatIDNEmail represents an internationalized email.
+	FormatIDNEmail Format = "idn-email"
+	// FormatHostname represents a host name.
+	FormatHostname Format = "hostname"
+	// FormatIDNHostname represents an internationalized host name.
+	FormatIDNHostname Format = "idn-hostname"
 	// FormatPassword represents a password. It's a hint to UIs to obscure input.
 	FormatPassword Format = "password"
 	// FormatUUID represents a UUID.
 	FormatUUID Format = "uuid"
 	// FormatURI represents a URI.
 	FormatURI Format = "uri"
+	// FormatURIReference represents a URI reference.
+	FormatURIReference Format = "uri-reference"
 	// FormatURIRef represents a URI reference.
 	FormatURIRef Format = "uriref"
+	// FormatURITemplate represents a URI template.
+	FormatURITemplate Format = "uri-template"
+	// FormatIRI represents an internationalized URI.
+	FormatIRI Format = "iri"
+	// FormatIRIReference represents an internationalized URI reference.
+	FormatIRIReference Format = "iri-reference"
+	// FormatJSONPointer represents a JSON pointer.
+	FormatJSONPointer Format = "json-pointer"
+	// FormatRelativeJSONPointer represents a relative JSON pointer.
+	FormatRelativeJSONPointer Format = "relative-json-pointer"
+	// FormatRegex represents a regular expression.
+	FormatRegex Format = "regex"
+	// FormatMediaRange represents a media type or media type range.
+	FormatMediaRange Format = "media-range"
 	// FormatZipCode represents a zip code.
 	FormatZipCode Format = "zip-code"
 	// FormatIPv4 represents an IPv4 address.
 	FormatIPv4 Format = "ipv4"
 	// FormatIPv6 represents an IPv6 address.
 	FormatIPv6 Format = "ipv6"
+	// FormatSFBinary represents a structured field byte sequence.
+	FormatSFBinary Format = "sf-binary"
+	// FormatSFBoolean represents a structured field boolean.
+	FormatSFBoolean Format = "sf-boolean"
+	// FormatSFDecimal represents a structured field decimal.
+	FormatSFDecimal Format = "sf-decimal"
+	// FormatSFInteger represents a structured field integer.
+	FormatSFInteger Format = "sf-integer"
+	// FormatSFString represents a structured field string.
+	FormatSFString Format = "sf-string"
+	// FormatSFToken represents a structured field token.
+	FormatSFToken Format = "sf-token"
 )
 
-var allFormats = []Format{
-	FormatInt32, FormatInt64,
-	FormatUint, FormatUint32, FormatUint64,
-	FormatFloat, FormatDouble,
-	FormatByte, FormatBinary,
-	FormatDate, FormatDateTime, FormatDuration,
-	FormatEmail, FormatPassword,
-	FormatUUID,
-	FormatURI, FormatURIRef, FormatZipCode,
-	FormatIPv4, FormatIPv6,
+// A FormatDefinition describes which data types a format applies to and, optionally, how to check values.
+type FormatDefinition struct {
+	// The data types the format is valid for.
+	Types []DataType
+	// Checks a value of one of the data types, given in the form produced by unmarshaling JSON into an `any`,
+	// except that numbers are of type Number. If nil, any value is accepted.
+	Check func(v any) error
 }
 
-// Validate validates the format.
+var (
+	formatsMu sync.RWMutex
+	formats   = map[Format]*FormatDefinition{
+		FormatInt8:                {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatInt8, math.MinInt8, math.MaxInt8)},
+		FormatInt16:               {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatInt16, math.MinInt16, math.MaxInt16)},
+		FormatInt32:               {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatInt32, math.MinInt32, math.MaxInt32)},
+		FormatInt64:               {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatInt64, math.MinInt64, math.MaxInt64)},
+		FormatUint8:               {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatUint8, 0, math.MaxUint8)},
+		FormatUint:                {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatUint, 0, math.MaxUint64)},
+		FormatUint32:              {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatUint32, 0, math.MaxUint32)},
+		FormatUint64:              {Types: []DataType{TypeInteger}, Check: checkIntegerRange(FormatUint64, 0, math.MaxUint64)},
+		FormatFloat:               {Types: []DataType{TypeNumber}, Check: checkFloat},
+		FormatDouble:              {Types: []DataType{TypeNumber}},
+		FormatDoubleInt:           {Types: []DataType{TypeInteger, TypeNumber}, Check: checkIntegerRange(FormatDoubleInt, -1<<53, 1<<53)},
+		FormatDecimal:             {Types: []DataType{TypeNumber, TypeString}, Check: checkString(FormatDecimal, reDecimal.MatchString)},
+		FormatDecimal128:          {Types: []DataType{TypeNumber, TypeString}, Check: checkString(FormatDecimal128, reDecimal.MatchString)},
+		FormatUnixTime:            {Types: []DataType{TypeInteger, TypeNumber}},
+		FormatByte:                {Types: []DataType{TypeString}, Check: checkString(FormatByte, isBase64)},
+		FormatBase64URL:           {Types: []DataType{TypeString}, Check: checkString(FormatBase64URL, isBase64URL)},
+		FormatBinary:              {Types: []DataType{TypeString}},
+		FormatChar:                {Types: []DataType{TypeString}, Check: checkString(FormatChar, isChar)},
+		FormatCommonMark:          {Types: []DataType{TypeString}},
+		FormatHTML:                {Types: []DataType{TypeString}},
+		FormatDate:                {Types: []DataType{TypeInteger, TypeString}, Check: checkString(FormatDate, isTime(time.DateOnly))},
+		FormatDateTime:            {Types: []DataType{TypeInteger, TypeString}, Check: checkString(FormatDateTime, isTime(time.RFC3339Nano))},
+		FormatDateTimeLocal:       {Types: []DataType{TypeString}, Check: checkString(FormatDateTimeLocal, isTime("2006-01-02T15:04:05.999999999"))},
+		FormatTime:                {Types: []DataType{TypeString}, Check: checkString(FormatTime, isTime("15:04:05.999999999Z07:00"))},
+		FormatTimeLocal:           {Types: []DataType{TypeString}, Check: checkString(FormatTimeLocal, isTime("15:04:05.999999999"))},
+		FormatHTTPDate:            {Types: []DataType{TypeString}, Check: checkString(FormatHTTPDate, isHTTPDate)},
+		FormatDuration:            {Types: []DataType{TypeInteger, TypeString}, Check: checkString(FormatDuration, isDuration)},
+		FormatEmail:               {Types: []DataType{TypeString}, Check: checkString(FormatEmail, isEmail)},
+		FormatIDNEmail:            {Types: []DataType{TypeString}, Check: checkString(FormatIDNEmail, isEmail)},
+		FormatHostname:            {Types: []DataType{TypeString}, Check: checkString(FormatHostname, isHostname)},
+		FormatIDNHostname:         {Types: []DataType{TypeString}},
+		FormatPassword:            {Types: []DataType{TypeString}},
+		FormatUUID:                {Types: []DataType{TypeString}, Check: checkString(FormatUUID, reUUID.MatchString)},
+		FormatURI:                 {Types: []DataType{TypeString}, Check: checkString(FormatURI, isAbsoluteURI)},
+		FormatURIReference:        {Types: []DataType{TypeString}, Check: checkString(FormatURIReference, isURIReference)},
+		FormatURIRef:              {Types: []DataType{TypeString}, Check: checkString(FormatURIRef, isURIReference)},
+		FormatURITemplate:         {Types: []DataType{TypeString}, Check: checkString(FormatURITemplate, isURITemplate)},
+		FormatIRI:                 {Types: []DataType{TypeString}, Check: checkString(FormatIRI, isAbsoluteURI)},
+		FormatIRIReference:        {Types: []DataType{TypeString}, Check: checkString(FormatIRIReference, isURIReference)},
+		FormatJSONPointer:         {Types: []DataType{TypeString}, Check: checkString(FormatJSONPointer, isJSONPointer)},
+		FormatRelativeJSONPointer: {Types: []DataType{TypeString}, Check: checkString(FormatRelativeJSONPointer, isRelativeJSONPointer)},
+		FormatRegex:               {Types: []DataType{TypeString}, Check: checkString(FormatRegex, isRegex)},
+		FormatMediaRange:          {Types: []DataType{TypeString}, Check: checkString(FormatMediaRange, isMediaRange)},
+		FormatZipCode:             {Types: []DataType{TypeString}},
+		FormatIPv4:                {Types: []DataType{TypeString}, Check: checkString(FormatIPv4, isIPv4)},
+		FormatIPv6:                {Types: []DataType{TypeString}, Check: checkString(FormatIPv6, isIPv6)},
+		FormatSFBinary:            {Types: []DataType{TypeString}},
+		FormatSFBoolean:           {Types: []DataType{TypeBoolean}},
+		FormatSFDecimal:           {Types: []DataType{TypeNumber}},
+		FormatSFInteger:           {Types: []DataType{TypeInteger}},
+		FormatSFString:            {Types: []DataType{TypeString}},
+		FormatSFToken:             {Types: []DataType{TypeString}},
+	}
+)
+
+// RegisterFormat makes a format known, so that schemas using it are checked against its definition.
+// A format that was registered before is replaced.
+func RegisterFormat(f Format, def *FormatDefinition) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+
+	formats[f] = def
+}
+
+// LookupFormat returns the definition of a registered format, if any.
+func LookupFormat(f Format) (*FormatDefinition, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+
+	def, ok := formats[f]
+	return def, ok
+}
+
+// Validate validates that the format is registered.
 func (f Format) Validate() error {
-	if slices.Contains(allFormats, f) {
+	if _, ok := LookupFormat(f); ok {
 		return nil
 	}
 
 	return &errpath.ErrInvalid[Format]{
-		Value: f,
-		Enum:  allFormats,
+		Value:   f,
+		Message: "unknown format, see RegisterFormat",
+	}
+}
+
+// validateType checks that the format is valid for the type of a schema.
+// Unknown formats and formats without data types are valid for any type.
+func (f Format) validateType(t SchemaType) error {
+	def, ok := LookupFormat(f)
+	if !ok || len(def.Types) == 0 || slices.ContainsFunc(def.Types, t.Includes) {
+		return nil
+	}
+
+	types := make([]string, len(def.Types))
+	for i, d := range def.Types {
+		types[i] = string(d)
+	}
+
+	if n := len(types); n > 1 {
+		types = append(types[:n-2], types[n-2]+" or "+types[n-1])
+	}
+
+	return &errpath.ErrInvalid[Format]{
+		Value:   f,
+		Message: fmt.Sprintf("only valid for %s type, got %s", strings.Join(types, ", "), t),
+	}
+}
+
+// check checks a value against the format, if the format applies to the kind of value.
+// Unknown formats and formats without a checker accept any value.
+func (f Format) check(v any) error {
+	def, ok := LookupFormat(f)
+	if !ok || def.Check == nil {
+		return nil
+	}
+
+	// numbers are checked by formats for integers, and vice versa, so that a checker can reject them
+	it := instanceType(v)
+	if !slices.Contains(def.Types, it) &&
+		!(it == TypeInteger && slices.Contains(def.Types, TypeNumber)) &&
+		!(it == TypeNumber && slices.Contains(def.Types, TypeInteger)) {
+		return nil
+	}
+
+	return def.Check(v)
+}
+
+// checkIntegerRange returns a checker for numbers that must be integers within a range.
+func checkIntegerRange(f Format, lo int64, hi uint64) func(any) error {
+	lower, upper := Number(strconv.FormatInt(lo, 10)), Number(strconv.FormatUint(hi, 10))
+
+	return func(v any) error {
+		n, ok := v.(Number)
+		switch {
+		case !ok:
+			return nil
+		case !n.IsInteger():
+			return fmt.Errorf("%v is not an integer", n)
+		case n.Cmp(lower) < 0 || n.Cmp(upper) > 0:
+			return fmt.Errorf("%v is out of range for %s", n, f)
+		default:
+			return nil
+		}
+	}
+}
+
+func checkFloat(v any) error {
+	if n, ok := v.(Number); ok && math.Abs(n.Float64()) > math.MaxFloat32 {
+		return fmt.Errorf("%v is out of range for %s", n, FormatFloat)
+	}
+
+	return nil
+}
+
+// checkString returns a checker for strings that must satisfy a condition.
+func checkString(f Format, valid func(string) bool) func(any) error {
+	return func(v any) error {
+		if s, ok := v.(string); ok && !valid(s) {
+			return fmt.Errorf("%q is not a valid %s", s, f)
+		}
+
+		return nil
+	}
+}
+
+var (
+	reDecimal  = regexp.MustCompile(`^-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][-+]?\d+)?$`)
+	reUUID     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	reDuration = regexp.MustCompile(`^P(?:\d+W|(?:\d+Y)?(?:\d+M)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:\.\d+)?S)?)?)$`)
+	reHostname = regexp.MustCompile(`^(?i:[a-z0-9](?:[-a-z0-9]{0,61}[a-z0-9])?)(?:\.(?i:[a-z0-9](?:[-a-z0-9]{0,61}[a-z0-9])?))*$`)
+	reRelative = regexp.MustCompile(`^(?:0|[1-9]\d*)(#|/.*)?$`)
+)
+
+func isBase64(s string) bool {
+	_, err := base64.StdEncoding.DecodeString(s)
+	return err == nil
+}
+
+func isBase64URL(s string) bool {
+	enc := base64.URLEncoding
+	if !strings.HasSuffix(s, "=") {
+		enc = base64.RawURLEncoding
+	}
+
+	_, err := enc.DecodeString(s)
+	return err == nil
+}
+
+func isChar(s string) bool { return utf8.RuneCountInString(s) == 1 }
+
+func isTime(layout string) func(string) bool {
+	return func(s string) bool {
+		_, err := time.Parse(layout, s)
+		return err == nil
+	}
+}
+
+func isHTTPDate(s string) bool {
+	_, err := http.ParseTime(s)
+	return err == nil
+}
+
+func isDuration(s string) bool {
+	return s != "P" && !strings.HasSuffix(s, "T") && reDuration.MatchString(s)
+}
+
+func isEmail(s string) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+func isHostname(s string) bool { return len(s) <= 253 && reHostname.MatchString(s) }
+
+func isAbsoluteURI(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
+
+func isURIReference(s string) bool {
+	_, err := url.Parse(s)
+	return err == nil
+}
+
+// isURITemplate reports whether the expressions of a URI template are properly enclosed in braces.
+func isURITemplate(s string) bool {
+	open := false
+	for _, r := range s {
+		switch {
+		case r == '{' && !open, r == '}' && open:
+			open = !open
+		case r == '{', r == '}':
+			return false
+		}
 	}
+
+	return !open
+}
+
+func isJSONPointer(s string) bool {
+	if s != "" && !strings.HasPrefix(s, "/") {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == '~' && (i+1 == len(s) || (s[i+1] != '0' && s[i+1] != '1')) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isRelativeJSONPointer(s string) bool {
+	m := reRelative.FindStringSubmatch(s)
+	return m != nil && (m[1] == "#" || isJSONPointer(m[1]))
+}
+
+// isRegex reports whether the string is a valid ECMA-262 regular expression.
+// Patterns that are valid but can't be evaluated, see Pattern.Unsupported, are regular expressions.
+func isRegex(s string) bool {
+	_, err := CompilePattern(s)
+	return err == nil
+}
+
+func isMediaRange(s string) bool {
+	_, _, err := mime.ParseMediaType(s)
+	return err == nil
+}
+
+func isIPv4(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is4()
+}
+
+func isIPv6(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	return err == nil && addr.Is6()
 }
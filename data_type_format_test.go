@@ -1,14 +1,15 @@
 package openapi_test
 
 import (
+	"encoding/json/jsontext"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/MarkRosemaker/errpath"
 	"github.com/MarkRosemaker/openapi"
 )
 
-const validFormats = `"int32", "int64", "uint", "uint32", "uint64", "float", "double", "byte", "binary", "date", "date-time", "duration", "email", "password", "uuid", "uri", "uriref", "zip-code", "ipv4", "ipv6"`
-
 func TestFormat(t *testing.T) {
 	// test a valid data type format
 	if err := openapi.FormatDateTime.Validate(); err != nil {
@@ -22,7 +23,90 @@ func TestFormat(t *testing.T) {
 	}
 
 	err = &errpath.ErrField{Field: "format", Err: err}
-	if want := `format ("foo") is invalid, must be one of: ` + validFormats; want != err.Error() {
+	if want := `format ("foo") is invalid: unknown format, see RegisterFormat`; want != err.Error() {
 		t.Fatalf("want: %s, got: %s", want, err)
 	}
 }
+
+func TestRegisterFormat(t *testing.T) {
+	t.Parallel()
+
+	const formatISBN openapi.Format = "isbn"
+	openapi.RegisterFormat(formatISBN, &openapi.FormatDefinition{
+		Types: []openapi.DataType{openapi.TypeString},
+		Check: func(v any) error {
+			if s := v.(string); len(strings.ReplaceAll(s, "-", "")) != 13 {
+				return errors.New("must have 13 digits")
+			}
+
+			return nil
+		},
+	})
+
+	if _, ok := openapi.LookupFormat(formatISBN); !ok {
+		t.Fatal("format is not registered")
+	}
+
+	s := &openapi.Schema{Type: openapi.Types(openapi.TypeString), Format: formatISBN}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ValidateJSON(jsontext.Value(`"978-3-16-148410-0"`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ValidateJSON(jsontext.Value(`"3-16-148410-0"`)); err == nil ||
+		err.Error() != `format at "": must have 13 digits` {
+		t.Fatalf("got: %v", err)
+	}
+
+	s.Type.Types = []openapi.DataType{openapi.TypeInteger}
+	if err := s.Validate(); err == nil ||
+		err.Error() != `format ("isbn") is invalid: only valid for string type, got integer` {
+		t.Fatalf("got: %v", err)
+	}
+}
+
+func TestFormat_Check(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		format  openapi.Format
+		valid   []string
+		invalid []string
+	}{
+		{openapi.FormatInt8, []string{`127`, `-128`}, []string{`128`, `1.5`}},
+		{openapi.FormatUint8, []string{`0`, `255`}, []string{`-1`, `256`}},
+		{openapi.FormatDoubleInt, []string{`9007199254740992`}, []string{`1e300`}},
+		{openapi.FormatDecimal, []string{`"-1.50"`, `1.5`}, []string{`"1,5"`, `"01"`}},
+		{openapi.FormatBase64URL, []string{`"_-8"`, `"_-8="`}, []string{`"+/8="`}},
+		{openapi.FormatChar, []string{`"ä"`}, []string{`""`, `"ab"`}},
+		{openapi.FormatTime, []string{`"23:20:50.52Z"`, `"08:30:06+02:00"`}, []string{`"08:30:06"`}},
+		{openapi.FormatTimeLocal, []string{`"08:30:06"`}, []string{`"08:30"`}},
+		{openapi.FormatDateTimeLocal, []string{`"2024-02-29T08:30:06"`}, []string{`"2024-02-29T08:30:06Z"`}},
+		{openapi.FormatHTTPDate, []string{`"Sun, 06 Nov 1994 08:49:37 GMT"`}, []string{`"1994-11-06"`}},
+		{openapi.FormatHostname, []string{`"api.example.com"`}, []string{`"-api.example.com"`, `"api..example.com"`}},
+		{openapi.FormatURITemplate, []string{`"/users/{id}{?fields}"`}, []string{`"/users/{id"`, `"/users/{{id}}"`}},
+		{openapi.FormatJSONPointer, []string{`""`, `"/a~1b/0"`}, []string{`"a"`, `"/a~2"`}},
+		{openapi.FormatRelativeJSONPointer, []string{`"0"`, `"1/a"`, `"2#"`}, []string{`"/a"`, `"01"`}},
+		{openapi.FormatRegex, []string{`"^[a-z]+$"`, `"^(?=.*\\d)\\w+$"`, `"a{1001}"`}, []string{`"[a-z"`, `"a{5,2}"`}},
+		{openapi.FormatMediaRange, []string{`"text/*"`}, []string{`"text/"`}},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			s := &openapi.Schema{Format: tc.format}
+
+			for _, v := range tc.valid {
+				if err := s.ValidateJSON(jsontext.Value(v)); err != nil {
+					t.Errorf("%s: %v", v, err)
+				}
+			}
+
+			for _, v := range tc.invalid {
+				if err := s.ValidateJSON(jsontext.Value(v)); err == nil {
+					t.Errorf("%s: expected error", v)
+				}
+			}
+		})
+	}
+}
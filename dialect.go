@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"encoding/json/v2"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+const (
+	// DialectOASBase is the default dialect of Schema Objects: JSON Schema Draft 2020-12 with the OpenAPI vocabulary.
+	DialectOASBase = "https://spec.openapis.org/oas/3.1/dialect/base"
+	// DialectJSONSchema202012 is plain JSON Schema Draft 2020-12.
+	DialectJSONSchema202012 = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// A Dialect describes which keywords Schema Objects may contain when they declare it,
+// either with `$schema` or through the `jsonSchemaDialect` of the document.
+//
+// Keywords of JSON Schema and OpenAPI are always allowed, since they are fields of Schema.
+// Any other keyword ends up in the extensions of the schema.
+type Dialect struct {
+	// Additional keywords that schemas of this dialect may contain, e.g. those of a custom vocabulary.
+	Keywords []string
+	// Whether any other unknown keyword is kept as an annotation.
+	// Otherwise, unknown keywords are invalid unless they are specification extensions, i.e. start with "x-".
+	AllowUnknownKeywords bool
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]*Dialect{
+		// JSON Schema, which the OpenAPI dialect builds on, collects unknown keywords as annotations
+		DialectOASBase:          {AllowUnknownKeywords: true},
+		DialectJSONSchema202012: {AllowUnknownKeywords: true},
+	}
+)
+
+// RegisterDialect makes a dialect available for documents and schemas that declare its URI.
+// A dialect that was registered before under the same URI is replaced.
+func RegisterDialect(uri string, d *Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	dialects[normalizeDialectURI(uri)] = d
+}
+
+// LookupDialect returns the dialect registered under the URI, if any.
+func LookupDialect(uri string) (*Dialect, bool) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	d, ok := dialects[normalizeDialectURI(uri)]
+	return d, ok
+}
+
+// normalizeDialectURI removes an empty fragment, as in "https://json-schema.org/draft/2020-12/schema#".
+func normalizeDialectURI(uri string) string {
+	return strings.TrimSuffix(uri, "#")
+}
+
+// validateDialect checks that the dialect of the URI is registered.
+func validateDialect(uri string) error {
+	if _, ok := LookupDialect(uri); ok {
+		return nil
+	}
+
+	return &errpath.ErrInvalid[string]{
+		Value:   uri,
+		Message: "unknown dialect, see RegisterDialect",
+	}
+}
+
+// validateKeywords checks that the keywords that are not fields of the schema are allowed in the dialect.
+func (d *Dialect) validateKeywords(ext Extensions) error {
+	if d.AllowUnknownKeywords || len(ext) == 0 {
+		return nil
+	}
+
+	m := map[string]any{}
+	if err := json.Unmarshal(ext, &m); err != nil {
+		return err
+	}
+
+	for k := range m {
+		if !strings.HasPrefix(k, "x-") && !slices.Contains(d.Keywords, k) {
+			return &errpath.ErrField{Field: k, Err: ErrUnknownField}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestDialect(t *testing.T) {
+	t.Parallel()
+
+	for _, uri := range []string{
+		openapi.DialectOASBase,
+		openapi.DialectJSONSchema202012,
+		openapi.DialectJSONSchema202012 + "#",
+	} {
+		if _, ok := openapi.LookupDialect(uri); !ok {
+			t.Fatalf("dialect %q is not registered", uri)
+		}
+	}
+
+	if _, ok := openapi.LookupDialect("https://example.com/unknown"); ok {
+		t.Fatal("unknown dialect should not be registered")
+	}
+}
+
+func TestDialect_Schemas(t *testing.T) {
+	t.Parallel()
+
+	openapi.RegisterDialect("https://example.com/dialect/units", &openapi.Dialect{
+		Keywords: []string{"unit"},
+	})
+
+	for _, tc := range []struct {
+		name string
+		data string
+		err  string
+	}{
+		{"default dialect", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"components": {"schemas": {
+	"Length": {"type": "number", "x-unit": "m"}
+}}
+}`, ``},
+		{"unknown keyword in default dialect", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"components": {"schemas": {
+	"Length": {"type": "number", "unit": "m"}
+}}
+}`, ``},
+		{"keyword of JSON Schema that is not a field", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"components": {"schemas": {
+	"A": {"type": "string", "contentMediaType": "application/json", "contentSchema": {"type": "object"}}
+}}
+}`, ``},
+		{"document dialect", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+"components": {"schemas": {
+	"Length": {"type": "number", "unit": "m"}
+}}
+}`, ``},
+		{"schema dialect", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"components": {"schemas": {
+	"Length": {
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {"value": {"type": "number", "unit": "m"}}
+	}
+}}
+}`, ``},
+		{"schema dialect overrides document dialect", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+"components": {"schemas": {
+	"Length": {
+		"$schema": "https://example.com/dialect/units",
+		"type": "object",
+		"properties": {"value": {"type": "number", "currency": "EUR"}}
+	}
+}}
+}`, `components.schemas["Length"].properties["value"].currency: unknown field or extension without "x-" prefix`},
+		{"custom vocabulary", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"jsonSchemaDialect": "https://example.com/dialect/units",
+"components": {"schemas": {
+	"Length": {"type": "number", "unit": "m"}
+}}
+}`, ``},
+		{"keyword not in custom vocabulary", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"jsonSchemaDialect": "https://example.com/dialect/units",
+"components": {"schemas": {
+	"Length": {"type": "number", "currency": "EUR"}
+}}
+}`, `components.schemas["Length"].currency: unknown field or extension without "x-" prefix`},
+		{"unknown schema dialect", `{
+"openapi": "3.1.0",
+"info": {"title": "Dialects", "version": "1.0"},
+"components": {"schemas": {
+	"Length": {"$schema": "https://example.com/dialect/unknown", "type": "number"}
+}}
+}`, `components.schemas["Length"].$schema ("https://example.com/dialect/unknown") is invalid: unknown dialect, see RegisterDialect`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := openapi.LoadFromData([]byte(tc.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = doc.Validate()
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
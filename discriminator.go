@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+// When request bodies or response payloads may be one of a number of different schemas, a Discriminator Object gives a hint about the expected schema of the document.
+// This hint can be used to aid in serialization, deserialization, and validation.
+// The Discriminator Object does this by implicitly or explicitly associating the possible values of a named property with alternative schemas.
+//
+// Note that `discriminator` MUST NOT change the validation outcome of the schema.
+//
+// ([Specification])
+//
+// [Specification]: https://spec.openapis.org/oas/v3.2.0.html#discriminator-object
+type Discriminator struct {
+	// REQUIRED. The name of the discriminating property in the payload that will hold the discriminating value.
+	// The discriminating property MAY be defined as required or optional, but when defined as optional the Discriminator Object MUST include a `defaultMapping` field.
+	PropertyName string `json:"propertyName" yaml:"propertyName"`
+	// An object to hold mappings between payload values and schema names or URI references.
+	// A payload value without an explicit mapping is implicitly mapped to the schema of the same name.
+	Mapping MapOfStrings `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	// The schema name or URI reference to a schema that is expected to validate the structure of the model
+	// when the discriminating property is not present in the payload or contains a value for which there is no explicit or implicit mapping.
+	DefaultMapping string `json:"defaultMapping,omitempty" yaml:"defaultMapping,omitempty"`
+	// This object MAY be extended with Specification Extensions.
+	Extensions Extensions `json:",embed" yaml:"-"`
+
+	// the schemas the mapping values resolve to
+	mapping map[string]*Schema
+	// the schema the default mapping resolves to
+	defaultMapping *Schema
+}
+
+func (d *Discriminator) Validate() error {
+	if d.PropertyName == "" {
+		return &errpath.ErrField{Field: "propertyName", Err: &errpath.ErrRequired{}}
+	}
+
+	for value, m := range d.Mapping.ByIndex() {
+		if m.Value == "" {
+			return &errpath.ErrField{Field: "mapping", Err: &errpath.ErrKey{Key: value, Err: &errpath.ErrRequired{}}}
+		}
+	}
+
+	return validateExtensions(d.Extensions)
+}
+
+// validateDiscriminator checks that each alternative defines the discriminating property and that the mapping targets are among the alternatives.
+func (s *Schema) validateDiscriminator() error {
+	d := s.Discriminator
+
+	// the property may be defined once for all alternatives
+	if defined, required := s.definesProperty(d.PropertyName); !defined || !required {
+		for _, alternatives := range []struct {
+			field string
+			list  SchemaRefList
+		}{{"oneOf", s.OneOf}, {"anyOf", s.AnyOf}} {
+			for i, alt := range alternatives.list {
+				if alt.Value == nil {
+					continue
+				}
+
+				altDefined, altRequired := alt.Value.definesProperty(d.PropertyName)
+				if !defined && !altDefined {
+					return &errpath.ErrField{Field: alternatives.field, Err: &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[string]{
+						Message: fmt.Sprintf("discriminator property %q does not exist", d.PropertyName),
+					}}}
+				}
+
+				// an optional property needs a default mapping
+				if !required && !altRequired && d.DefaultMapping == "" {
+					return &errpath.ErrField{Field: alternatives.field, Err: &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[string]{
+						Message: fmt.Sprintf("discriminator property %q is not required", d.PropertyName),
+					}}}
+				}
+			}
+		}
+	}
+
+	if len(s.OneOf) == 0 && len(s.AnyOf) == 0 {
+		return nil
+	}
+
+	for value, m := range d.Mapping.ByIndex() {
+		if target := d.mapping[value]; target != nil && s.isAlternative(target) ||
+			target == nil && s.alternative(m.Value) != nil {
+			continue
+		}
+
+		return &errpath.ErrField{Field: "discriminator", Err: &errpath.ErrField{
+			Field: "mapping",
+			Err: &errpath.ErrKey{Key: value, Err: &errpath.ErrInvalid[string]{
+				Value:   m.Value,
+				Message: "not one of the schemas in oneOf or anyOf",
+			}},
+		}}
+	}
+
+	return nil
+}
+
+// definesProperty reports whether the schema, or one of the schemas in its allOf, defines the property and whether it requires it.
+func (s *Schema) definesProperty(name string) (defined, required bool) {
+	_, defined = s.Properties[name]
+	required = slices.Contains(s.Required, name)
+
+	for _, sub := range s.AllOf {
+		if sub.Value != nil {
+			d, r := sub.Value.definesProperty(name)
+			defined, required = defined || d, required || r
+		}
+	}
+
+	return defined, required
+}
+
+// isAlternative reports whether the schema is one of the schemas in oneOf or anyOf.
+func (s *Schema) isAlternative(target *Schema) bool {
+	return slices.ContainsFunc(slices.Concat(s.OneOf, s.AnyOf), func(alt *SchemaRef) bool {
+		return alt.Value == target
+	})
+}
+
+// alternative returns the schema in oneOf or anyOf that is referenced by the given schema name or URI reference.
+func (s *Schema) alternative(nameOrRef string) *Schema {
+	for _, alt := range slices.Concat(s.OneOf, s.AnyOf) {
+		if alt.Ref != nil && (alt.Ref.Identifier == nameOrRef || schemaName(alt.Ref.Identifier) == nameOrRef) {
+			return alt.Value
+		}
+	}
+
+	return nil
+}
+
+// schemaName returns the name of the schema component the URI reference points to, if any.
+func schemaName(ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	name, ok := strings.CutPrefix(u.Fragment, "/components/schemas/")
+	if !ok || strings.Contains(name, "/") {
+		return ""
+	}
+
+	return name
+}
+
+// Discriminate returns the schema that the JSON object is expected to validate against,
+// according to the value of the discriminating property and the Discriminator Object of the schema.
+//
+// A value is looked up in the mapping first and otherwise matched against the names of the schemas in oneOf or anyOf.
+// If the property is absent or the value cannot be mapped, the schema of the default mapping is returned, if any.
+func (s *Schema) Discriminate(data jsontext.Value) (*Schema, error) {
+	d := s.Discriminator
+	if d == nil {
+		return nil, errors.New("schema has no discriminator")
+	}
+
+	obj := map[string]jsontext.Value{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	var value string
+	prop, ok := obj[d.PropertyName]
+	if ok {
+		if err := json.Unmarshal(prop, &value); err != nil {
+			return nil, &errpath.ErrKey{Key: d.PropertyName, Err: err}
+		}
+
+		if target := d.target(s, value); target != nil {
+			return target, nil
+		}
+	}
+
+	if d.defaultMapping != nil {
+		return d.defaultMapping, nil
+	}
+
+	if d.DefaultMapping != "" {
+		if target := s.alternative(d.DefaultMapping); target != nil {
+			return target, nil
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("discriminator property %q is missing", d.PropertyName)
+	}
+
+	return nil, fmt.Errorf("no schema for discriminator value %q", value)
+}
+
+// target returns the schema the discriminating value is mapped to, explicitly or implicitly.
+func (d *Discriminator) target(s *Schema, value string) *Schema {
+	if target, ok := d.mapping[value]; ok {
+		return target
+	}
+
+	if m, ok := d.Mapping[value]; ok {
+		return s.alternative(m.Value)
+	}
+
+	return s.alternative(value)
+}
+
+func (l *loader) resolveDiscriminator(d *Discriminator) error {
+	d.mapping = make(map[string]*Schema, len(d.Mapping))
+	for value, m := range d.Mapping.ByIndex() {
+		target, err := l.lookupSchema(m.Value)
+		if err != nil {
+			return &errpath.ErrField{Field: "mapping", Err: &errpath.ErrKey{Key: value, Err: err}}
+		}
+
+		d.mapping[value] = target
+	}
+
+	if d.DefaultMapping != "" {
+		target, err := l.lookupSchema(d.DefaultMapping)
+		if err != nil {
+			return &errpath.ErrField{Field: "defaultMapping", Err: err}
+		}
+
+		d.defaultMapping = target
+	}
+
+	return nil
+}
+
+// lookupSchema returns the schema identified by a schema name or a URI reference.
+func (l *loader) lookupSchema(nameOrRef string) (*Schema, error) {
+	if reKey.MatchString(nameOrRef) {
+		u := &url.URL{Fragment: "/components/schemas/" + nameOrRef}
+		if l.document != nil {
+			u = l.document.ResolveReference(u)
+		}
+
+		if s, ok := l.schemas[refKey(u)]; ok {
+			return s, nil
+		}
+	}
+
+	return l.lookupSchemaURI(nameOrRef)
+}
+
+// lookupSchemaURI returns the schema identified by a URI reference.
+func (l *loader) lookupSchemaURI(ref string) (*Schema, error) {
+	if u, err := l.resolveURI(ref); err == nil {
+		if s, ok := l.schemas[refKey(u)]; ok {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("couldn't resolve %q", ref)
+}
@@ -0,0 +1,150 @@
+package openapi_test
+
+import (
+	"encoding/json/jsontext"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestDiscriminator_JSON(t *testing.T) {
+	t.Parallel()
+
+	testJSON(t, []byte(`{
+  "propertyName": "petType"
+}`), &openapi.Discriminator{})
+
+	testJSON(t, []byte(`{
+  "propertyName": "petType",
+  "mapping": {
+    "dog": "#/components/schemas/Dog",
+    "monster": "https://gigantic-server.com/schemas/Monster/schema.json"
+  },
+  "defaultMapping": "OtherPet",
+  "x-foo": "bar"
+}`), &openapi.Discriminator{})
+}
+
+func TestDiscriminator_Validate_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		d   openapi.Discriminator
+		err string
+	}{
+		{openapi.Discriminator{}, `propertyName is required`},
+		{openapi.Discriminator{
+			PropertyName: "petType",
+			Mapping:      openapi.MapOfStrings{"dog": {}},
+		}, `mapping["dog"] is required`},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			if err := tc.d.Validate(); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %s", tc.err, err)
+			}
+		})
+	}
+}
+
+const petsWithDiscriminator = `{
+"openapi": "3.2.0",
+"info": {"title": "Pets", "version": "1.0"},
+"components": {"schemas": {
+	"Pet": {
+		"oneOf": [
+			{"$ref": "#/components/schemas/Cat"},
+			{"$ref": "#/components/schemas/Dog"},
+			{"$ref": "#/components/schemas/Lizard"}
+		],
+		"discriminator": {
+			"propertyName": "petType",
+			"mapping": {"dog": "Dog", "lizard": "#/components/schemas/Lizard"},
+			"defaultMapping": "Cat"
+		}
+	},
+	"BasePet": {
+		"type": "object",
+		"properties": {"petType": {"type": "string"}}
+	},
+	"Cat": {"allOf": [
+		{"$ref": "#/components/schemas/BasePet"},
+		{"type": "object", "properties": {"meows": {"type": "boolean"}}}
+	]},
+	"Dog": {"allOf": [
+		{"$ref": "#/components/schemas/BasePet"},
+		{"type": "object", "properties": {"barks": {"type": "boolean"}}}
+	]},
+	"Lizard": {
+		"type": "object",
+		"properties": {"petType": {"type": "string"}, "lovesRocks": {"type": "boolean"}},
+		"required": ["petType"]
+	}
+}}
+}`
+
+func TestSchema_Discriminate(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(petsWithDiscriminator))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	schemas := doc.Components.Schemas
+	pet := schemas["Pet"]
+
+	for _, tc := range []struct {
+		data string
+		want string
+	}{
+		{`{"petType": "dog", "barks": true}`, "Dog"},      // explicit mapping by name
+		{`{"petType": "lizard"}`, "Lizard"},               // explicit mapping by reference
+		{`{"petType": "Dog"}`, "Dog"},                     // implicit mapping
+		{`{"meows": true}`, "Cat"},                        // default mapping for missing property
+		{`{"petType": "hamster", "meows": false}`, "Cat"}, // default mapping for unknown value
+	} {
+		t.Run(tc.data, func(t *testing.T) {
+			got, err := pet.Discriminate(jsontext.Value(tc.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := schemas[tc.want]; got != want {
+				t.Fatalf("want %s, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSchema_Discriminate_Error(t *testing.T) {
+	t.Parallel()
+
+	dog := &openapi.SchemaRef{
+		Ref:   &openapi.Reference{Identifier: "#/components/schemas/Dog"},
+		Value: &openapi.Schema{Type: openapi.Types(openapi.TypeObject)},
+	}
+	pet := &openapi.Schema{
+		OneOf:         openapi.SchemaRefList{dog},
+		Discriminator: &openapi.Discriminator{PropertyName: "petType"},
+	}
+
+	for _, tc := range []struct {
+		s    *openapi.Schema
+		data string
+		err  string
+	}{
+		{&openapi.Schema{}, `{}`, `schema has no discriminator`},
+		{pet, `{}`, `discriminator property "petType" is missing`},
+		{pet, `{"petType": "Cat"}`, `no schema for discriminator value "Cat"`},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			if _, err := tc.s.Discriminate(jsontext.Value(tc.data)); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/MarkRosemaker/errpath"
 )
@@ -20,11 +21,14 @@ var ErrEmptyDocument = errors.New("document must contain at least one paths fiel
 type Document struct {
 	// REQUIRED. This string MUST be the version number of the OpenAPI Specification that the OpenAPI document uses. The openapi field SHOULD be used by tooling to interpret the OpenAPI document. This is not related to the API info.version string.
 	OpenAPI string `json:"openapi" yaml:"openapi"`
+	// The self-assigned URI of this document, which also serves as its base URI for resolving relative references. This MUST be in the form of a URI-reference and MUST NOT contain a fragment.
+	// If absent, the retrieval URI of the document is used as its base URI.
+	Self *url.URL `json:"$self,omitempty" yaml:"$self,omitempty"`
 	// REQUIRED. Provides metadata about the API. The metadata MAY be used by tooling as required.
 	Info *Info `json:"info,omitempty" yaml:"info,omitempty"`
 	// The default value for the $schema keyword within Schema Objects contained within this OAS document. This MUST be in the form of a URI.
 	// Default: "https://spec.openapis.org/oas/3.1/dialect/base"
-	// NOTE: Anything other than the default value is not supported.
+	// Dialects other than the default and plain JSON Schema Draft 2020-12 need to be registered with RegisterDialect.
 	JSONSchemaDialect *url.URL `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"`
 	// An array of Server Objects, which provide connectivity information to a target server. If the servers property is not provided, or is an empty array, the default value would be a Server Object with a url value of /.
 	Servers Servers `json:"servers,omitempty" yaml:"servers,omitempty"`
@@ -42,6 +46,9 @@ type Document struct {
 	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 	// This object MAY be extended with Specification Extensions.
 	Extensions Extensions `json:",embed" yaml:",embed"`
+
+	// the URI the document was loaded from, if known
+	location *url.URL
 }
 
 // reOpenAPIVersion is a regular expression that matches the OpenAPI version.
@@ -65,6 +72,13 @@ func (d *Document) Validate() error {
 		}
 	}
 
+	if d.Self != nil && d.Self.Fragment != "" {
+		return &errpath.ErrField{Field: "$self", Err: &errpath.ErrInvalid[string]{
+			Value:   d.Self.String(),
+			Message: "must not contain a fragment",
+		}}
+	}
+
 	if d.Info == nil {
 		return &errpath.ErrField{Field: "info", Err: &errpath.ErrRequired{}}
 	}
@@ -73,13 +87,10 @@ func (d *Document) Validate() error {
 		return &errpath.ErrField{Field: "info", Err: err}
 	}
 
-	const defaultJSONSchemaDialect = "https://spec.openapis.org/oas/3.1/dialect/base"
-	if d.JSONSchemaDialect != nil &&
-		d.JSONSchemaDialect.String() != defaultJSONSchemaDialect {
-		return &errpath.ErrField{Field: "jsonSchemaDialect", Err: &errpath.ErrInvalid[string]{
-			Value: d.JSONSchemaDialect.String(),
-			Enum:  []string{defaultJSONSchemaDialect},
-		}}
+	if d.JSONSchemaDialect != nil {
+		if err := validateDialect(d.JSONSchemaDialect.String()); err != nil {
+			return &errpath.ErrField{Field: "jsonSchemaDialect", Err: err}
+		}
 	}
 
 	if err := d.Servers.Validate(); err != nil {
@@ -103,6 +114,12 @@ func (d *Document) Validate() error {
 		return &errpath.ErrField{Field: "components", Err: err}
 	}
 
+	// some keywords of schemas changed their form between OpenAPI 3.0 and 3.1
+	c := &documentChecker{visited: map[any]bool{}, checkVersion: true, openAPI30: strings.HasPrefix(d.OpenAPI, "3.0.")}
+	if d.check(c); len(c.errs) > 0 {
+		return c.errs[0]
+	}
+
 	if err := d.Security.Validate(); err != nil {
 		return &errpath.ErrField{Field: "security", Err: err}
 	}
@@ -126,6 +143,41 @@ func (d *Document) SortMaps() {
 	d.Components.SortMaps()
 }
 
+// dialect returns the dialect of the Schema Objects in the document, or nil if it is the default or not registered.
+func (d *Document) dialect() *Dialect {
+	if d.JSONSchemaDialect == nil {
+		return nil
+	}
+
+	dialect, _ := LookupDialect(d.JSONSchemaDialect.String())
+	return dialect
+}
+
+// baseURI returns the base URI of the document for resolving relative references,
+// given the URI it was retrieved from, which may be nil.
+func (d *Document) baseURI(location *url.URL) *url.URL {
+	switch {
+	case d.Self == nil:
+		return location
+	case location == nil:
+		return d.Self
+	default:
+		return location.ResolveReference(d.Self)
+	}
+}
+
+// ResolveReference resolves a URL, e.g. that of a relative server, against the base URI of the document,
+// i.e. its `$self` field resolved against the location it was loaded from.
+// If the base URI is unknown, the URL is returned as is.
+func (d *Document) ResolveReference(u *url.URL) *url.URL {
+	base := d.baseURI(d.location)
+	if base == nil {
+		return u
+	}
+
+	return base.ResolveReference(u)
+}
+
 func (l *loader) collectDocument(doc *Document, ref ref) {
 	l.collectPaths(doc.Paths, append(ref, "paths"))
 	l.collectWebhooks(doc.Webhooks, append(ref, "webhooks"))
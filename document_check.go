@@ -0,0 +1,362 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+// ValidateExamples checks every example and default value in the document against the schema that applies to it.
+// This includes examples and defaults within schemas as well as examples of parameters, headers and media types,
+// whether given inline or referenced. Examples of media types that are not JSON are skipped.
+//
+// Examples of request bodies are checked against the request view of their schema,
+// and examples of responses against the response view, see Schema.RequestView and Schema.ResponseView.
+//
+// Unlike Validate, which stops at the first error, all findings are reported, each one at the location of the example.
+// The document is expected to be valid.
+func (d *Document) ValidateExamples() error {
+	c := &documentChecker{visited: map[any]bool{}, checkExamples: true}
+	d.check(c)
+
+	return errors.Join(c.errs...)
+}
+
+// Warnings returns findings that do not make the document invalid, but may indicate a problem,
+// each one at its location. Currently, these are paths that overlap, e.g. `/files/{path}` and `/files/latest`,
+// formats of schemas that are unknown, see RegisterFormat,
+// and patterns of schemas that can't be evaluated, see Pattern.Unsupported.
+func (d *Document) Warnings() []error {
+	c := &documentChecker{visited: map[any]bool{}, warn: true}
+	for _, err := range d.Paths.overlaps() {
+		c.errs = append(c.errs, &errpath.ErrField{Field: "paths", Err: err})
+	}
+
+	d.check(c)
+
+	return c.errs
+}
+
+// check walks the document with the checker.
+func (d *Document) check(c *documentChecker) {
+	at := func(err error) error { return err }
+
+	for path, p := range d.Paths.ByIndex() {
+		c.pathItem(p, inKey(inField(at, "paths"), string(path)))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(d.Webhooks)) {
+		p := d.Webhooks[name]
+		c.pathItemRef(p, inKey(inField(at, "webhooks"), name))
+	}
+
+	c.components(&d.Components, inField(at, "components"))
+}
+
+// documentChecker collects the findings of ValidateExamples or Warnings.
+type documentChecker struct {
+	errs []error
+	// whether to check examples and defaults against their schemas
+	checkExamples bool
+	// whether to report unknown formats and unsupported patterns
+	warn bool
+	// whether to report keywords in the form of another OpenAPI version than that of the document
+	checkVersion bool
+	// whether the document is an OpenAPI 3.0 document
+	openAPI30 bool
+	// the objects that were already checked, as they may be reached more than once
+	visited map[any]bool
+}
+
+// inField returns a function that wraps an error in the field of the location.
+func inField(at func(error) error, field string) func(error) error {
+	return func(err error) error { return at(&errpath.ErrField{Field: field, Err: err}) }
+}
+
+// inKey returns a function that wraps an error in the key of the location.
+func inKey(at func(error) error, key string) func(error) error {
+	return func(err error) error { return at(&errpath.ErrKey{Key: key, Err: err}) }
+}
+
+// inIndex returns a function that wraps an error in the index of the location.
+func inIndex(at func(error) error, i int) func(error) error {
+	return func(err error) error { return at(&errpath.ErrIndex{Index: i, Err: err}) }
+}
+
+// visit reports whether the object is checked for the first time.
+func (c *documentChecker) visit(v any) bool {
+	if c.visited[v] {
+		return false
+	}
+
+	c.visited[v] = true
+	return true
+}
+
+// check validates the value against the schema, if both are present.
+func (c *documentChecker) check(s *Schema, v jsontext.Value, at func(error) error) {
+	if !c.checkExamples || s == nil || v == nil {
+		return
+	}
+
+	if err := s.ValidateJSON(v); err != nil {
+		c.errs = append(c.errs, at(err))
+	}
+}
+
+// examples validates the value of each example against the schema.
+func (c *documentChecker) examples(s *Schema, exs Examples, at func(error) error) {
+	for name, ex := range exs.ByIndex() {
+		if ex.Value != nil {
+			c.check(s, ex.Value.Value, inField(inKey(at, name), "value"))
+		}
+	}
+}
+
+func (c *documentChecker) components(cs *Components, at func(error) error) {
+	for name, s := range cs.Schemas.ByIndex() {
+		c.schema(s, inKey(inField(at, "schemas"), name))
+	}
+
+	for name, r := range cs.Responses.ByIndex() {
+		c.responseRef(r, inKey(inField(at, "responses"), name))
+	}
+
+	for name, p := range cs.Parameters.ByIndex() {
+		c.parameterRef(p, inKey(inField(at, "parameters"), name))
+	}
+
+	for name, r := range cs.RequestBodies.ByIndex() {
+		c.requestBodyRef(r, inKey(inField(at, "requestBodies"), name))
+	}
+
+	for name, h := range cs.Headers.ByIndex() {
+		c.headerRef(h, inKey(inField(at, "headers"), name))
+	}
+
+	for name, cb := range cs.Callbacks.ByIndex() {
+		if cb.Ref == nil && cb.Value != nil {
+			c.callback(*cb.Value, inKey(inField(at, "callbacks"), name))
+		}
+	}
+
+	for name, p := range cs.PathItems.ByIndex() {
+		c.pathItemRef(p, inKey(inField(at, "pathItems"), name))
+	}
+}
+
+// pathItemRef checks a path item, unless it is referenced and therefore checked where it is defined.
+func (c *documentChecker) pathItemRef(p *PathItemRef, at func(error) error) {
+	if p.Ref == nil && p.Value != nil {
+		c.pathItem(p.Value, at)
+	}
+}
+
+func (c *documentChecker) pathItem(p *PathItem, at func(error) error) {
+	c.parameters(p.Parameters, inField(at, "parameters"))
+
+	for method, op := range p.Operations {
+		c.operation(op, inField(at, method))
+	}
+}
+
+func (c *documentChecker) operation(op *Operation, at func(error) error) {
+	c.parameters(op.Parameters, inField(at, "parameters"))
+
+	if op.RequestBody != nil {
+		c.requestBodyRef(op.RequestBody, inField(at, "requestBody"))
+	}
+
+	for code, r := range op.Responses.ByIndex() {
+		c.responseRef(r, inKey(inField(at, "responses"), string(code)))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(op.Callbacks)) {
+		c.callback(op.Callbacks[name], inKey(inField(at, "callbacks"), name))
+	}
+}
+
+func (c *documentChecker) callback(cb Callback, at func(error) error) {
+	for expr, p := range cb.ByIndex() {
+		c.pathItemRef(p, inKey(at, string(expr)))
+	}
+}
+
+func (c *documentChecker) parameters(ps ParameterList, at func(error) error) {
+	for i, p := range ps {
+		c.parameterRef(p, inIndex(at, i))
+	}
+}
+
+// parameterRef checks a parameter, unless it is referenced and therefore checked where it is defined.
+func (c *documentChecker) parameterRef(p *ParameterRef, at func(error) error) {
+	if p.Ref != nil || p.Value == nil || !c.visit(p.Value) {
+		return
+	}
+
+	c.schema(p.Value.Schema, inField(at, "schema"))
+	c.check(p.Value.Schema, p.Value.Example, inField(at, "example"))
+	c.examples(p.Value.Schema, p.Value.Examples, inField(at, "examples"))
+	c.content(p.Value.Content, (*Schema).RequestView, inField(at, "content"))
+}
+
+// headerRef checks a header, unless it is referenced and therefore checked where it is defined.
+func (c *documentChecker) headerRef(h *HeaderRef, at func(error) error) {
+	if h.Ref != nil || h.Value == nil || !c.visit(h.Value) {
+		return
+	}
+
+	c.schema(h.Value.Schema, inField(at, "schema"))
+	c.check(h.Value.Schema, h.Value.Example, inField(at, "example"))
+	c.examples(h.Value.Schema, h.Value.Examples, inField(at, "examples"))
+	c.content(h.Value.Content, (*Schema).ResponseView, inField(at, "content"))
+}
+
+// requestBodyRef checks a request body, unless it is referenced and therefore checked where it is defined.
+func (c *documentChecker) requestBodyRef(r *RequestBodyRef, at func(error) error) {
+	if r.Ref == nil && r.Value != nil && c.visit(r.Value) {
+		c.content(r.Value.Content, (*Schema).RequestView, inField(at, "content"))
+	}
+}
+
+// responseRef checks a response, unless it is referenced and therefore checked where it is defined.
+func (c *documentChecker) responseRef(r *ResponseRef, at func(error) error) {
+	if r.Ref != nil || r.Value == nil || !c.visit(r.Value) {
+		return
+	}
+
+	for name, h := range r.Value.Headers.ByIndex() {
+		c.headerRef(h, inKey(inField(at, "headers"), name))
+	}
+
+	c.content(r.Value.Content, (*Schema).ResponseView, inField(at, "content"))
+}
+
+// content checks the media types, validating examples against the view of their schema, if given.
+func (c *documentChecker) content(content Content, view func(*Schema) *Schema, at func(error) error) {
+	for mr, mt := range content.ByIndex() {
+		at := inKey(at, string(mr))
+		if mt.Schema == nil {
+			continue
+		}
+
+		if mt.Schema.Ref == nil {
+			c.schema(mt.Schema.Value, inField(at, "schema"))
+		}
+
+		if s := mt.Schema.Value; s != nil && mr.isJSON() {
+			if view != nil {
+				s = view(s)
+			}
+
+			c.check(s, mt.Example, inField(at, "example"))
+			c.examples(s, mt.Examples, inField(at, "examples"))
+		}
+	}
+}
+
+// warnings reports the parts of the schema that are valid but not fully supported,
+// i.e. unknown formats and patterns that use ECMA-262 features RE2 lacks.
+func (c *documentChecker) warnings(s *Schema, at func(error) error) {
+	if s.Format != "" {
+		if err := s.Format.Validate(); err != nil {
+			c.errs = append(c.errs, inField(at, "format")(err))
+		}
+	}
+
+	if s.Pattern != nil {
+		if err := s.Pattern.Unsupported(); err != nil {
+			c.errs = append(c.errs, inField(at, "pattern")(unsupportedPattern(s.Pattern, err)))
+		}
+	}
+
+	for pattern := range s.PatternProperties.ByIndex() {
+		if p, err := CompilePattern(pattern); err == nil && p.Unsupported() != nil {
+			c.errs = append(c.errs, inKey(inField(at, "patternProperties"), pattern)(unsupportedPattern(p, p.Unsupported())))
+		}
+	}
+}
+
+// exclusiveBound reports an exclusive bound in the form of another OpenAPI version,
+// i.e. a number in OpenAPI 3.0 or a boolean since OpenAPI 3.1.
+func (c *documentChecker) exclusiveBound(b *ExclusiveBound, at func(error) error) {
+	switch {
+	case b == nil:
+	case c.openAPI30 && b.Number != nil:
+		c.errs = append(c.errs, at(&errpath.ErrInvalid[Number]{Value: *b.Number, Message: "must be a boolean in OpenAPI 3.0"}))
+	case !c.openAPI30 && b.Number == nil:
+		c.errs = append(c.errs, at(&errpath.ErrInvalid[bool]{Value: b.Bool, Message: "must be a number since OpenAPI 3.1"}))
+	}
+}
+
+// unsupportedPattern returns an error for a pattern that can't be used as is.
+func unsupportedPattern(p *Pattern, err error) error {
+	return &errpath.ErrInvalid[string]{
+		Value:   p.String(),
+		Message: fmt.Sprintf("%v: %v", ErrUnsupportedPattern, err),
+	}
+}
+
+// schema checks the schema and its subschemas that are not referenced.
+func (c *documentChecker) schema(s *Schema, at func(error) error) {
+	if s == nil || s.Boolean != nil || !c.visit(s) {
+		return
+	}
+
+	if c.warn {
+		c.warnings(s, at)
+	}
+
+	if c.checkVersion {
+		c.exclusiveBound(s.ExclusiveMin, inField(at, "exclusiveMinimum"))
+		c.exclusiveBound(s.ExclusiveMax, inField(at, "exclusiveMaximum"))
+	}
+
+	c.check(s, s.Default, inField(at, "default"))
+	c.check(s, s.Example, inField(at, "example"))
+
+	for i, ex := range s.Examples {
+		c.check(s, ex, inIndex(inField(at, "examples"), i))
+	}
+
+	schemaRef := func(r *SchemaRef, at func(error) error) {
+		if r != nil && r.Ref == nil {
+			c.schema(r.Value, at)
+		}
+	}
+
+	schemaRefList := func(rs SchemaRefList, field string) {
+		for i, r := range rs {
+			schemaRef(r, inIndex(inField(at, field), i))
+		}
+	}
+
+	schemaRefs := func(rs SchemaRefs, field string) {
+		for name, r := range rs.ByIndex() {
+			schemaRef(r, inKey(inField(at, field), name))
+		}
+	}
+
+	schemaRefs(s.Defs, "$defs")
+	schemaRefList(s.AllOf, "allOf")
+	schemaRefList(s.OneOf, "oneOf")
+	schemaRefList(s.AnyOf, "anyOf")
+	schemaRef(s.Not, inField(at, "not"))
+	schemaRef(s.If, inField(at, "if"))
+	schemaRef(s.Then, inField(at, "then"))
+	schemaRef(s.Else, inField(at, "else"))
+	schemaRefList(s.PrefixItems, "prefixItems")
+	schemaRef(s.Items, inField(at, "items"))
+	schemaRef(s.Contains, inField(at, "contains"))
+	schemaRef(s.UnevaluatedItems, inField(at, "unevaluatedItems"))
+	schemaRefs(s.Properties, "properties")
+	schemaRefs(s.PatternProperties, "patternProperties")
+	schemaRef(s.AdditionalProperties, inField(at, "additionalProperties"))
+	schemaRef(s.PropertyNames, inField(at, "propertyNames"))
+	schemaRefs(s.DependentSchemas, "dependentSchemas")
+	schemaRef(s.UnevaluatedProperties, inField(at, "unevaluatedProperties"))
+}
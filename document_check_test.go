@@ -0,0 +1,187 @@
+package openapi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestDocument_ValidateExamples(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range []string{
+		"examples/openapi.yaml",
+		"examples/v3.0/api-with-examples.yaml",
+		"examples/v3.0/petstore-expanded.yaml",
+		"examples/v3.0/uspto.yaml",
+	} {
+		t.Run(file, func(t *testing.T) {
+			doc, err := openapi.LoadFromFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := doc.ValidateExamples(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestDocument_ValidateExamples_Error(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"info": {"title": "Pets", "version": "1.0"},
+"paths": {"/pets/{id}": {
+	"parameters": [{
+		"name": "id", "in": "path", "required": true,
+		"schema": {"type": "integer", "minimum": 1},
+		"example": 0
+	}, {
+		"name": "filter", "in": "query",
+		"content": {"application/json": {
+			"schema": {
+				"type": "object",
+				"properties": {"id": {"type": "integer", "readOnly": true}, "name": {"type": "string"}},
+				"additionalProperties": false
+			},
+			"example": {"id": 1, "name": "Tom"}
+		}}
+	}],
+	"put": {
+		"requestBody": {"content": {
+			"application/json": {
+				"schema": {"$ref": "#/components/schemas/Pet"},
+				"examples": {
+					"good": {"value": {"name": "Tom"}},
+					"bad": {"$ref": "#/components/examples/Nameless"}
+				}
+			},
+			"text/plain": {"schema": {"type": "object"}, "example": "Tom"}
+		}},
+		"responses": {"200": {
+			"description": "The pet.",
+			"headers": {"X-Rate-Limit": {"schema": {"type": "integer"}, "example": "many"}},
+			"content": {"application/json": {
+				"schema": {"$ref": "#/components/schemas/Pet"},
+				"example": {"name": "Tom"}
+			}}
+		}}
+	}
+}},
+"components": {
+	"schemas": {
+		"Pet": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer", "readOnly": true},
+				"name": {"type": "string"},
+				"kind": {"type": "string", "enum": ["cat", "dog"]},
+				"age": {"type": "integer", "minimum": 0, "default": -1}
+			},
+			"required": ["id", "name"],
+			"examples": [{"id": 1, "name": "Tom", "kind": "cat"}, {"id": 1, "kind": "dog"}]
+		}
+	},
+	"examples": {"Nameless": {"value": {"kind": "cat"}}}
+}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`paths["/pets/{id}"].parameters[0].example: minimum at "": must be >= 1, got 0`,
+		`paths["/pets/{id}"].parameters[1].content["application/json"].example: additionalProperties at "/id": no value is allowed`,
+		`paths["/pets/{id}"].PUT.requestBody.content["application/json"].examples["bad"].value: required at "": property "name" is missing`,
+		`paths["/pets/{id}"].PUT.responses["200"].headers["X-Rate-Limit"].example: type at "": expected integer, got string`,
+		`paths["/pets/{id}"].PUT.responses["200"].content["application/json"].example: required at "": property "id" is missing`,
+		`components.schemas["Pet"].examples[1]: required at "": property "name" is missing`,
+		`components.schemas["Pet"].properties["age"].default: minimum at "": must be >= 0, got -1`,
+	}
+
+	err = doc.ValidateExamples()
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+
+	if want := strings.Join(want, "\n"); err.Error() != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, err)
+	}
+}
+
+func TestDocument_Warnings(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"info": {"title": "Books", "version": "1.0"},
+"components": {"schemas": {
+	"Book": {
+		"type": "object",
+		"properties": {
+			"isbn": {"type": "string", "format": "isbn-13"},
+			"price": {"type": "string", "format": "decimal"},
+			"code": {"type": "string", "pattern": "^(?!0)\\d+$"}
+		},
+		"patternProperties": {"^x-(?=.)": {"type": "string"}}
+	}
+}}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings := doc.Warnings()
+	want := []string{
+		`components.schemas["Book"].patternProperties["^x-(?=.)"] ("^x-(?=.)") is invalid: pattern is not supported: lookahead assertion`,
+		`components.schemas["Book"].properties["isbn"].format ("isbn-13") is invalid: unknown format, see RegisterFormat`,
+		`components.schemas["Book"].properties["code"].pattern ("^(?!0)\\d+$") is invalid: pattern is not supported: negative lookahead assertion`,
+	}
+
+	if len(warnings) != len(want) {
+		t.Fatalf("want %d warnings, got: %v", len(want), warnings)
+	}
+
+	for i, w := range want {
+		if warnings[i].Error() != w {
+			t.Fatalf("want: %s, got: %s", w, warnings[i])
+		}
+	}
+}
+
+func TestDocument_Warnings_Paths(t *testing.T) {
+	t.Parallel()
+
+	doc := &openapi.Document{}
+	for _, path := range []openapi.Path{"/files/{path}", "/files/latest", "/files/{name}.json", "/files/{a}/{b}", "/users/{id}"} {
+		doc.Paths.Set(path, &openapi.PathItem{})
+	}
+
+	want := []string{
+		`paths["/files/latest"]: overlaps with "/files/{path}"`,
+		`paths["/files/{name}.json"]: overlaps with "/files/{path}"`,
+	}
+
+	warnings := doc.Warnings()
+	if len(warnings) != len(want) {
+		t.Fatalf("want %d warnings, got: %v", len(want), warnings)
+	}
+
+	for i, w := range want {
+		if warnings[i].Error() != w {
+			t.Fatalf("want: %s, got: %s", w, warnings[i])
+		}
+	}
+}
@@ -22,6 +22,16 @@ func TestDocument_JSON(t *testing.T) {
 	  "paths": {"/": {}},
 	  "security": [{}]
 }`), &openapi.Document{})
+
+	testJSON(t, []byte(`{
+	  "openapi": "3.2.0",
+	  "$self": "https://example.com/api/openapi.json",
+	  "info": {
+	    "title": "Sample Pet Store App",
+		"version": "1.0.0"
+	  },
+	  "paths": {"/": {}}
+}`), &openapi.Document{})
 }
 
 func TestDocument_Validate(t *testing.T) {
@@ -112,6 +122,10 @@ func TestDocumentValidate_Error(t *testing.T) {
 		{&openapi.Document{
 			OpenAPI: "3.1.0",
 		}, `info is required`},
+		{&openapi.Document{
+			OpenAPI: "3.2.0",
+			Self:    mustParseURL("https://example.com/openapi.json#foo"),
+		}, `$self ("https://example.com/openapi.json#foo") is invalid: must not contain a fragment`},
 		{&openapi.Document{
 			OpenAPI: "3.1.0",
 			Info:    &openapi.Info{},
@@ -120,7 +134,7 @@ func TestDocumentValidate_Error(t *testing.T) {
 			OpenAPI:           "3.1.0",
 			Info:              &openapi.Info{Title: "Sample API", Version: "1.0.0"},
 			JSONSchemaDialect: mustParseURL("https://example.com"),
-		}, `jsonSchemaDialect ("https://example.com") is invalid, must be one of: "https://spec.openapis.org/oas/3.1/dialect/base"`},
+		}, `jsonSchemaDialect ("https://example.com") is invalid: unknown dialect, see RegisterDialect`},
 		{&openapi.Document{
 			OpenAPI: "3.1.0",
 			Info:    &openapi.Info{Title: "Sample API", Version: "1.0.0"},
@@ -158,6 +172,27 @@ func TestDocumentValidate_Error(t *testing.T) {
 				Schemas: openapi.Schemas{"Pet": &openapi.Schema{}},
 			},
 		}, `components.schemas["Pet"].type is required`},
+		{&openapi.Document{
+			OpenAPI: "3.1.0",
+			Info:    &openapi.Info{Title: "Sample API", Version: "1.0.0"},
+			Components: openapi.Components{
+				Schemas: openapi.Schemas{"Age": &openapi.Schema{
+					Type:         openapi.Types(openapi.TypeInteger),
+					Min:          new(openapi.Number("0")),
+					ExclusiveMin: &openapi.ExclusiveBound{Bool: true},
+				}},
+			},
+		}, `components.schemas["Age"].exclusiveMinimum (true) is invalid: must be a number since OpenAPI 3.1`},
+		{&openapi.Document{
+			OpenAPI: "3.0.3",
+			Info:    &openapi.Info{Title: "Sample API", Version: "1.0.0"},
+			Components: openapi.Components{
+				Schemas: openapi.Schemas{"Age": &openapi.Schema{
+					Type:         openapi.Types(openapi.TypeInteger),
+					ExclusiveMax: &openapi.ExclusiveBound{Number: new(openapi.Number("150"))},
+				}},
+			},
+		}, `components.schemas["Age"].exclusiveMaximum (150) is invalid: must be a boolean in OpenAPI 3.0`},
 		{&openapi.Document{
 			OpenAPI:  "3.1.0",
 			Info:     &openapi.Info{Title: "Sample API", Version: "1.0.0"},
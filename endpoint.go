@@ -0,0 +1,133 @@
+package openapi
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// EndpointKind describes where an operation is defined in a document.
+type EndpointKind string
+
+const (
+	// The operation is defined by a path of the document.
+	EndpointKindPath EndpointKind = "path"
+	// The operation is defined by a webhook of the document.
+	EndpointKindWebhook EndpointKind = "webhook"
+	// The operation is defined by a callback of another operation.
+	EndpointKindCallback EndpointKind = "callback"
+)
+
+// Endpoint is an operation along with its path item and document,
+// so that the servers, parameters and security requirements that the operation inherits can be computed.
+type Endpoint struct {
+	// Where the operation is defined.
+	Kind EndpointKind
+	// The path template, the name of the webhook, or the runtime expression of the callback.
+	Key string
+	// The name of the callback, if the operation is defined by a callback.
+	Callback string
+	// The method of the operation, in upper case.
+	Method string
+	// The path item that defines the operation.
+	PathItem *PathItem
+	// The operation.
+	Operation *Operation
+	// The endpoint that defines the callback, if the operation is defined by a callback.
+	Parent *Endpoint
+
+	doc *Document
+}
+
+// Endpoints iterates over the operations of the paths and then the webhooks of the document,
+// each followed by the operations of its callbacks.
+// Webhooks and callbacks are ordered by name, and unresolved path items are skipped.
+func (d *Document) Endpoints() iter.Seq[*Endpoint] {
+	return func(yield func(*Endpoint) bool) {
+		for path, item := range d.Paths.ByIndex() {
+			if !d.yieldEndpoints(yield, &Endpoint{Kind: EndpointKindPath, Key: string(path), PathItem: item}) {
+				return
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(d.Webhooks)) {
+			item := d.Webhooks[name].Value
+			if item == nil {
+				continue
+			}
+
+			if !d.yieldEndpoints(yield, &Endpoint{Kind: EndpointKindWebhook, Key: name, PathItem: item}) {
+				return
+			}
+		}
+	}
+}
+
+// yieldEndpoints yields the operations of the path item of the template and their callbacks.
+func (d *Document) yieldEndpoints(yield func(*Endpoint) bool, template *Endpoint) bool {
+	for method, op := range template.PathItem.Operations {
+		e := *template
+		e.Method, e.Operation, e.doc = method, op, d
+
+		if !yield(&e) {
+			return false
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(op.Callbacks)) {
+			for expr, item := range op.Callbacks[name].ByIndex() {
+				if item.Value == nil {
+					continue
+				}
+
+				if !d.yieldEndpoints(yield, &Endpoint{
+					Kind:     EndpointKindCallback,
+					Key:      string(expr),
+					Callback: name,
+					PathItem: item.Value,
+					Parent:   &e,
+				}) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// Servers returns the servers of the operation, or else those of the path item.
+//
+// Operations of paths fall back to the servers of the document, which default to a server with the URL `/`.
+// Webhooks and callbacks are requests to the API consumer, so they do not inherit the servers of the document
+// and nil is returned if neither the operation nor the path item declares any.
+func (e *Endpoint) Servers() Servers {
+	switch {
+	case len(e.Operation.Servers) > 0:
+		return e.Operation.Servers
+	case len(e.PathItem.Servers) > 0:
+		return e.PathItem.Servers
+	case e.Kind != EndpointKindPath:
+		return nil
+	case e.doc != nil && len(e.doc.Servers) > 0:
+		return e.doc.Servers
+	default:
+		return Servers{{URL: "/"}}
+	}
+}
+
+// Parameters returns the parameters of the path item, overridden by the parameters of the operation with the same name and location.
+// The parameters of the path item come first, followed by the parameters that only the operation declares.
+// References need to be resolved, e.g. by loading the document, for parameters to be overridden.
+func (e *Endpoint) Parameters() ParameterList {
+	return e.PathItem.Parameters.override(e.Operation.Parameters)
+}
+
+// Security returns the security requirements of the operation, or else those of the document.
+// An empty list, as opposed to a nil one, means that the operation requires no security.
+func (e *Endpoint) Security() SecurityRequirements {
+	if e.Operation.Security != nil || e.doc == nil {
+		return e.Operation.Security
+	}
+
+	return e.doc.Security
+}
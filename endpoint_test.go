@@ -0,0 +1,115 @@
+package openapi_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+const endpointDoc = `{
+"openapi": "3.2.0",
+"info": {"title": "Pets", "version": "1.0"},
+"servers": [{"url": "https://api.example.com"}],
+"security": [{"apiKey": []}],
+"paths": {
+	"/pets": {
+		"servers": [{"url": "https://pets.example.com"}],
+		"parameters": [
+			{"name": "limit", "in": "query", "schema": {"type": "integer"}},
+			{"$ref": "#/components/parameters/trace"}
+		],
+		"get": {
+			"parameters": [
+				{"name": "limit", "in": "query", "required": true, "schema": {"type": "integer"}},
+				{"name": "limit", "in": "header", "schema": {"type": "integer"}}
+			],
+			"responses": {"200": {"description": "OK"}}
+		},
+		"post": {
+			"servers": [{"url": "https://write.example.com"}],
+			"security": [],
+			"responses": {"201": {"description": "Created"}},
+			"callbacks": {
+				"onCreated": {
+					"{$request.body#/callbackUrl}": {
+						"post": {"responses": {"200": {"description": "OK"}}}
+					}
+				}
+			}
+		}
+	},
+	"/health": {"get": {"security": [{}], "responses": {"200": {"description": "OK"}}}}
+},
+"webhooks": {
+	"newPet": {"post": {"responses": {"200": {"description": "OK"}}}}
+},
+"components": {
+	"parameters": {"trace": {"name": "X-Trace", "in": "header", "schema": {"type": "string"}}},
+	"securitySchemes": {"apiKey": {"type": "apiKey", "name": "key", "in": "header"}}
+}
+}`
+
+func TestDocument_Endpoints(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(endpointDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for e := range doc.Endpoints() {
+		var servers, params, security []string
+		for _, s := range e.Servers() {
+			servers = append(servers, s.URL)
+		}
+
+		for _, p := range e.Parameters() {
+			params = append(params, fmt.Sprintf("%s:%s:%t", p.Value.In, p.Value.Name, p.Value.Required))
+		}
+
+		sec := e.Security()
+		for _, req := range sec {
+			for name := range req {
+				security = append(security, string(name))
+			}
+		}
+
+		if sec != nil && len(sec) == 0 {
+			security = []string{"none"}
+		}
+
+		got = append(got, fmt.Sprintf("%s %s %s %v %v %v", e.Kind, e.Method, e.Key, servers, params, security))
+	}
+
+	want := []string{
+		"path GET /pets [https://pets.example.com] [query:limit:true header:X-Trace:false header:limit:false] [apiKey]",
+		"path POST /pets [https://write.example.com] [query:limit:false header:X-Trace:false] [none]",
+		"callback POST {$request.body#/callbackUrl} [] [] [apiKey]",
+		"path GET /health [https://api.example.com] [] []",
+		"webhook POST newPet [] [] [apiKey]",
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("want:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func TestEndpoint_Servers_Default(t *testing.T) {
+	t.Parallel()
+
+	doc := &openapi.Document{}
+	doc.Paths.Set("/", &openapi.PathItem{Get: &openapi.Operation{}})
+
+	for e := range doc.Endpoints() {
+		if servers := e.Servers(); len(servers) != 1 || servers[0].URL != "/" {
+			t.Fatalf("want the default server, got: %v", servers)
+		}
+
+		if e.Security() != nil {
+			t.Fatalf("want no security, got: %v", e.Security())
+		}
+	}
+}
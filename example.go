@@ -43,5 +43,5 @@ func (l *loader) collectExample(ex *Example, ref ref) {
 }
 
 func (l *loader) resolveExampleRef(ex *ExampleRef) error {
-	return resolveRef(ex, l.examples, nil)
+	return resolveRef(l, ex, l.examples, nil)
 }
@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+)
+
+// ExclusiveBound is the value of the `exclusiveMinimum` or `exclusiveMaximum` keyword of a Schema Object.
+//
+// In OpenAPI 3.0, the keyword is a boolean that makes the corresponding `minimum` or `maximum` exclusive.
+// Since OpenAPI 3.1, following JSON Schema Draft 2020-12, the keyword is a number that is the exclusive bound itself.
+type ExclusiveBound struct {
+	// The exclusive bound (OpenAPI 3.1 and later). If nil, the keyword is a boolean.
+	Number *Number
+	// Whether `minimum` or `maximum` is exclusive (OpenAPI 3.0). Only used if Number is nil.
+	Bool bool
+}
+
+var _ json.MarshalerTo = (*ExclusiveBound)(nil)
+
+// MarshalJSONTo marshals the bound as number or boolean, depending on which form it has.
+func (b *ExclusiveBound) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if b.Number != nil {
+		return b.Number.MarshalJSONTo(enc)
+	}
+
+	return enc.WriteToken(jsontext.Bool(b.Bool))
+}
+
+var _ json.UnmarshalerFrom = (*ExclusiveBound)(nil)
+
+// UnmarshalJSONFrom unmarshals the bound from either a number or a boolean.
+func (b *ExclusiveBound) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	switch kind := dec.PeekKind(); kind {
+	case 't', 'f':
+		*b = ExclusiveBound{}
+		return json.UnmarshalDecode(dec, &b.Bool)
+	case '0':
+		*b = ExclusiveBound{Number: new(Number)}
+		return b.Number.UnmarshalJSONFrom(dec)
+	default:
+		return fmt.Errorf("expected number or boolean, got %s", kind)
+	}
+}
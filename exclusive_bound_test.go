@@ -0,0 +1,52 @@
+package openapi_test
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestExclusiveBound_JSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in   string
+		want openapi.ExclusiveBound
+	}{
+		{`true`, openapi.ExclusiveBound{Bool: true}},
+		{`false`, openapi.ExclusiveBound{}},
+		{`3.5`, openapi.ExclusiveBound{Number: new(openapi.Number("3.5"))}},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			b := &openapi.ExclusiveBound{}
+			if err := json.Unmarshal([]byte(tc.in), b); err != nil {
+				t.Fatal(err)
+			}
+
+			if b.Bool != tc.want.Bool || (b.Number == nil) != (tc.want.Number == nil) ||
+				(b.Number != nil && *b.Number != *tc.want.Number) {
+				t.Fatalf("got: %+v, want: %+v", b, tc.want)
+			}
+
+			out, err := json.Marshal(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out) != tc.in {
+				t.Fatalf("got: %s, want: %s", out, tc.in)
+			}
+		})
+	}
+}
+
+func TestExclusiveBound_JSON_Error(t *testing.T) {
+	t.Parallel()
+
+	err := json.Unmarshal([]byte(`"3"`), &openapi.ExclusiveBound{})
+	semErr := errAs[json.SemanticError](t, err)
+	if want := "expected number or boolean, got string"; semErr.Err.Error() != want {
+		t.Fatalf("got: %v, want: %v", semErr.Err, want)
+	}
+}
@@ -75,7 +75,7 @@ func (h *Header) Validate() error {
 			}}
 		}
 
-		if h.Schema == nil || (h.Schema.Type != TypeArray && h.Schema.Type != TypeObject) {
+		if h.Schema == nil || (!h.Schema.Type.Includes(TypeArray) && !h.Schema.Type.Includes(TypeObject)) {
 			return &errpath.ErrField{Field: "explode", Err: &errpath.ErrInvalid[bool]{
 				Value:   true,
 				Message: fmt.Sprintf("property has no effect when schema type is not array or object, got %q", h.Schema.Type),
@@ -108,7 +108,7 @@ func (l *loader) collectHeader(h *Header, ref ref) {
 }
 
 func (l *loader) resolveHeaderRef(h *HeaderRef) error {
-	return resolveRef(h, l.headers, l.resolveHeader)
+	return resolveRef(l, h, l.headers, l.resolveHeader)
 }
 
 func (l *loader) resolveHeader(h *Header) error {
@@ -108,21 +108,21 @@ func TestHeader_Validate_Error(t *testing.T) {
 			Explode: yes,
 		}, `explode (true) is invalid: property has no effect when schema is not present`},
 		{openapi.Header{
-			Schema:  &openapi.Schema{Type: openapi.TypeString},
+			Schema:  &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Explode: yes,
 		}, `explode (true) is invalid: property has no effect when schema type is not array or object, got "string"`},
 		{openapi.Header{
-			Schema:   &openapi.Schema{Type: openapi.TypeString},
+			Schema:   &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Example:  jsontext.Value("foo"),
 			Examples: openapi.Examples{},
 		}, `example and examples are mutually exclusive`},
 		{openapi.Header{
-			Schema:   &openapi.Schema{Type: openapi.TypeString},
+			Schema:   &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Examples: openapi.Examples{"foo": invalidExample},
 		}, `examples["foo"]: value and externalValue are mutually exclusive`},
 
 		{openapi.Header{
-			Schema:     &openapi.Schema{Type: openapi.TypeString},
+			Schema:     &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Extensions: jsontext.Value(`{"foo": "bar"}`),
 		}, `foo: ` + openapi.ErrUnknownField.Error()},
 	} {
@@ -50,7 +50,17 @@ func (l *Link) Validate() error {
 		return errors.New("operationRef or operationId must be set")
 	}
 
-	// NOTE: We don't check RequestBody or Parameters yet.
+	for name, value := range l.Parameters.ByIndex() {
+		if err := RuntimeExpression(value.Value).Validate(); err != nil {
+			return &errpath.ErrField{Field: "parameters", Err: &errpath.ErrKey{Key: name, Err: err}}
+		}
+	}
+
+	if l.RequestBody != "" {
+		if err := l.RequestBody.Validate(); err != nil {
+			return &errpath.ErrField{Field: "requestBody", Err: err}
+		}
+	}
 
 	l.Description = strings.TrimSpace(l.Description)
 
@@ -72,5 +82,5 @@ func (l *loader) collectLinkRef(lr *LinkRef, ref ref) {
 func (l *loader) collectLink(link *Link, ref ref) { l.links[ref.String()] = link }
 
 func (l *loader) resolveLinkRef(lr *LinkRef) error {
-	return resolveRef(lr, l.links, nil)
+	return resolveRef(l, lr, l.links, nil)
 }
@@ -124,6 +124,10 @@ func TestLink_Validate_Error(t *testing.T) {
 			OperationRef: "myRef",
 			Extensions:   jsontext.Value(`{"foo":"bar"}`),
 		}, `foo: ` + openapi.ErrUnknownField.Error()},
+		{openapi.Link{
+			OperationID: "getUser",
+			RequestBody: "{$request.body#/user",
+		}, `requestBody: offset 0: missing closing brace`},
 	} {
 		t.Run(tc.err, func(t *testing.T) {
 			if err := tc.link.Validate(); err == nil {
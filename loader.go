@@ -5,6 +5,7 @@ import (
 	"encoding/json/jsontext"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 )
@@ -21,6 +22,18 @@ type loader struct {
 	examples        map[string]*Example
 	securitySchemes map[string]*SecurityScheme
 	callbacks       map[string]*Callback
+
+	// the schema resources, i.e. documents and schemas with an `$id`, by the key of their URI
+	resources map[string]*schemaResource
+
+	// the URI the document currently being loaded was retrieved from, if known
+	location *url.URL
+	// the base URI against which relative references are currently resolved
+	base *url.URL
+	// the base URI of the document currently being resolved, e.g. to look up schema components by name
+	document *url.URL
+	// the dialect of the schemas currently being resolved
+	dialect *Dialect
 }
 
 func (l *loader) reset() {
@@ -34,6 +47,7 @@ func (l *loader) reset() {
 	l.examples = map[string]*Example{}
 	l.securitySchemes = map[string]*SecurityScheme{}
 	l.callbacks = map[string]*Callback{}
+	l.resources = map[string]*schemaResource{}
 }
 
 // newLoader returns an empty Loader
@@ -48,18 +62,83 @@ func LoadFromFile(location string) (*Document, error) {
 
 // LoadFromFile reads an OpenAPI specification from a file and parses it into a structured format.
 func (l *loader) LoadFromFile(location string) (*Document, error) {
+	doc, err := decodeFile(location)
+	if err != nil {
+		return nil, err
+	}
+
+	// the retrieval URI is the default base URI for resolving relative references
+	if l.location, err = fileURL(location); err != nil {
+		return nil, err
+	}
+
+	l.reset()
+
+	if err := l.collectResolveRefs(doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// LoadFromFiles reads several OpenAPI specifications from files and parses them into a structured format.
+// References between the documents are resolved against each document's base URI, i.e. its `$self` field or, if absent, the location of the file.
+// Two documents declaring an absolute `$self` can therefore reference each other regardless of where the files are stored.
+// The documents are returned in the order of the given locations.
+func LoadFromFiles(locations ...string) ([]*Document, error) {
+	return newLoader().LoadFromFiles(locations...)
+}
+
+// LoadFromFiles reads several OpenAPI specifications from files and parses them into a structured format.
+// References between the documents are resolved against each document's base URI, i.e. its `$self` field or, if absent, the location of the file.
+func (l *loader) LoadFromFiles(locations ...string) ([]*Document, error) {
+	l.reset()
+
+	docs := make([]*Document, len(locations))
+	bases := make([]*url.URL, len(locations))
+
+	// collect the components of all documents first, so that they can reference each other
+	for i, location := range locations {
+		doc, err := decodeFile(location)
+		if err != nil {
+			return nil, err
+		}
+
+		loc, err := fileURL(location)
+		if err != nil {
+			return nil, err
+		}
+
+		doc.location = loc
+		docs[i], bases[i] = doc, doc.baseURI(loc)
+		l.collectDocument(doc, ref{refKey(bases[i])})
+	}
+
+	for i, doc := range docs {
+		l.base, l.document = bases[i], bases[i]
+		l.dialect = doc.dialect()
+		if err := l.resolveDocument(doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", locations[i], err)
+		}
+	}
+
+	return docs, nil
+}
+
+// decodeFile reads an OpenAPI specification from a file without resolving its references.
+func decodeFile(location string) (*Document, error) {
 	f, err := os.Open(location)
 	if err != nil {
 		return nil, err
 	}
 
-	// determine the file type and load accordingly
+	// determine the file type and decode accordingly
 	doc, err := func() (*Document, error) {
 		switch ext := filepath.Ext(location); ext {
 		case ".json":
-			return l.LoadFromReaderJSON(f)
+			return decodeReaderJSON(f)
 		case ".yaml", ".yml":
-			return l.LoadFromReaderYAML(f)
+			return decodeReaderYAML(f)
 		default:
 			return nil, fmt.Errorf("unsupported file extension: %s", ext)
 		}
@@ -68,6 +147,16 @@ func (l *loader) LoadFromFile(location string) (*Document, error) {
 	return doc, errorsJoin(err, f.Close())
 }
 
+// fileURL returns the file URL of the given location.
+func fileURL(location string) (*url.URL, error) {
+	abs, err := filepath.Abs(location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}, nil
+}
+
 func LoadFromData(data []byte) (*Document, error) {
 	return newLoader().LoadFromData(data)
 }
@@ -10,8 +10,8 @@ import (
 func (l *loader) LoadFromReaderJSON(r io.Reader) (*Document, error) {
 	l.reset()
 
-	doc := &Document{}
-	if err := json.UnmarshalRead(r, doc, jsonOpts); err != nil {
+	doc, err := decodeReaderJSON(r)
+	if err != nil {
 		return nil, err
 	}
 
@@ -60,3 +60,13 @@ func isJSONRead(r io.Reader) (bool, error) {
 		return b[0] == '{', nil
 	}
 }
+
+// decodeReaderJSON decodes an OpenAPI specification without resolving its references.
+func decodeReaderJSON(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	if err := json.UnmarshalRead(r, doc, jsonOpts); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
@@ -97,6 +97,36 @@ func TestLoadFromReader(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+
+	t.Run("yaml with boolean schemas", func(t *testing.T) {
+		t.Parallel()
+
+		doc, err := openapi.LoadFromDataYAML([]byte(`openapi: 3.1.0
+info:
+  title: Strict
+  version: "1.0"
+components:
+  schemas:
+    Strict:
+      type: object
+      properties:
+        name:
+          type: string
+      additionalProperties: false
+    Anything: true
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := doc.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		if b := doc.Components.Schemas["Strict"].AdditionalProperties.Value.Boolean; b == nil || *b {
+			t.Fatalf("expected additionalProperties to be false, got %v", b)
+		}
+	})
 }
 
 func TestLoadFromReader_Error(t *testing.T) {
@@ -10,8 +10,8 @@ import (
 func (l *loader) LoadFromReaderYAML(r io.Reader) (*Document, error) {
 	l.reset()
 
-	doc := &Document{}
-	if err := yaml.UnmarshalRead(r, doc, jsonOpts); err != nil {
+	doc, err := decodeReaderYAML(r)
+	if err != nil {
 		return nil, err
 	}
 
@@ -42,3 +42,13 @@ func (l *loader) LoadFromDataYAML(data []byte) (*Document, error) {
 
 	return doc, nil
 }
+
+// decodeReaderYAML decodes an OpenAPI specification without resolving its references.
+func decodeReaderYAML(r io.Reader) (*Document, error) {
+	doc := &Document{}
+	if err := yaml.UnmarshalRead(r, doc, jsonOpts); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
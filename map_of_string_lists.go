@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"iter"
+
+	"github.com/MarkRosemaker/ordmap"
+)
+
+// MapOfStringLists is an ordered map of string lists.
+type MapOfStringLists map[string]StringList
+
+// ByIndex returns a sequence of key-value pairs ordered by index.
+func (m MapOfStringLists) ByIndex() iter.Seq2[string, StringList] {
+	return ordmap.ByIndex(m, getIndexStringList)
+}
+
+// Sort sorts the map by key and sets the indices accordingly.
+func (m MapOfStringLists) Sort() {
+	ordmap.Sort(m, setIndexStringList)
+}
+
+// Set sets a value in the map, adding it at the end of the order.
+func (m *MapOfStringLists) Set(key string, l StringList) {
+	ordmap.Set(m, key, l, getIndexStringList, setIndexStringList)
+}
+
+var _ json.MarshalerTo = (*MapOfStringLists)(nil)
+
+// MarshalJSONTo marshals the key-value pairs in order.
+func (m *MapOfStringLists) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return ordmap.MarshalJSONTo(m, enc)
+}
+
+var _ json.UnmarshalerFrom = (*MapOfStringLists)(nil)
+
+// UnmarshalJSONFrom unmarshals the key-value pairs in order and sets the indices.
+func (m *MapOfStringLists) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return ordmap.UnmarshalJSONFrom(m, dec, setIndexStringList)
+}
+
+// StringList is a list of strings within a MapOfStringLists.
+type StringList struct {
+	Values []string
+
+	idx int
+}
+
+var _ json.UnmarshalerFrom = (*StringList)(nil)
+
+// UnmarshalJSONFrom unmarshals the values of the StringList.
+func (l *StringList) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return json.UnmarshalDecode(dec, &l.Values)
+}
+
+var _ json.MarshalerTo = (*StringList)(nil)
+
+// MarshalJSONTo marshals the values of the StringList.
+func (l *StringList) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return json.MarshalEncode(enc, l.Values)
+}
+
+func getIndexStringList(l StringList) int               { return l.idx }
+func setIndexStringList(l StringList, i int) StringList { l.idx = i; return l }
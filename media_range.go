@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"mime"
+	"strings"
 )
 
 // MediaRange represents a media type or media type range. It is the key type in the Content map.
@@ -32,3 +33,9 @@ func (mr MediaRange) Validate() error {
 	_, _, err := mime.ParseMediaType(string(mr))
 	return err
 }
+
+// isJSON reports whether the media range is JSON, e.g. `application/json` or `application/vnd.github+json`.
+func (mr MediaRange) isJSON() bool {
+	mediaType, _, err := mime.ParseMediaType(string(mr))
+	return err == nil && (mediaType == MediaRangeJSON || strings.HasSuffix(mediaType, "+json"))
+}
@@ -0,0 +1,237 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Number is a JSON number, kept as its original text so that no precision is lost,
+// e.g. of 64-bit integers or decimal amounts.
+//
+// Numbers are compared exactly by their mathematical value, so that e.g. `1`, `1.0` and `1e0` are equal.
+type Number string
+
+// maxExactExponent is the largest absolute decimal exponent of a number that Rat evaluates,
+// so that a short text can't make it arbitrarily expensive.
+const maxExactExponent = 1000
+
+// String returns the text of the number.
+func (n Number) String() string { return string(n) }
+
+// GoString returns the text of the number, which is also Go syntax for it.
+func (n Number) GoString() string { return string(n) }
+
+// Float64 returns the nearest floating-point value of the number.
+// Numbers beyond the range of float64 are returned as positive or negative infinity.
+func (n Number) Float64() float64 {
+	f, _ := strconv.ParseFloat(string(n), 64)
+	return f
+}
+
+// Rat returns the exact value of the number.
+// It returns false if the number is invalid or its exponent is too large to evaluate exactly.
+func (n Number) Rat() (*big.Rat, bool) {
+	if i := strings.IndexAny(string(n), "eE"); i >= 0 {
+		exp, err := strconv.Atoi(string(n[i+1:]))
+		if err != nil || exp < -maxExactExponent || exp > maxExactExponent {
+			return nil, false
+		}
+	}
+
+	return new(big.Rat).SetString(string(n))
+}
+
+// IsInteger reports whether the number is a whole number, e.g. `3`, `3.0` or `3e2`.
+func (n Number) IsInteger() bool {
+	d, ok := n.decimal()
+	return ok && (d.digits == "" || d.exp >= len(d.digits))
+}
+
+// Sign returns -1, 0 or +1 depending on whether the number is negative, zero or positive.
+func (n Number) Sign() int {
+	d, ok := n.decimal()
+	if !ok {
+		return n.Cmp("0")
+	}
+
+	return d.sign()
+}
+
+// Cmp compares two numbers by their value and returns -1, 0 or +1
+// depending on whether n is less than, equal to or greater than m.
+func (n Number) Cmp(m Number) int {
+	if a, ok := n.decimal(); ok {
+		if b, ok := m.decimal(); ok {
+			return a.cmp(b)
+		}
+	}
+
+	a, b := n.Float64(), m.Float64()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsMultipleOf reports whether dividing the number by m results in an integer.
+// The divisor must be greater than 0.
+func (n Number) IsMultipleOf(m Number) bool {
+	if a, ok := n.decimal(); ok {
+		if b, ok := m.decimal(); ok && b.sign() > 0 {
+			return a.isMultipleOf(b)
+		}
+	}
+
+	q := n.Float64() / m.Float64()
+	return !math.IsInf(q, 0) && q == math.Trunc(q)
+}
+
+// decimal is the value of a number in scientific notation, i.e. `0.digits` times 10 to the power of exp.
+// It is exact for any exponent, as no arithmetic is done on the digits.
+type decimal struct {
+	neg bool
+	// the significant digits, without leading or trailing zeros, empty for zero
+	digits string
+	exp    int
+}
+
+// decimal parses the number, returning false if it is not a valid JSON number or its exponent overflows.
+func (n Number) decimal() (decimal, bool) {
+	s := string(n)
+	if !jsontext.Value(s).IsValid() || s == "" || s[0] == '"' {
+		return decimal{}, false
+	}
+
+	d := decimal{}
+	if s[0] == '-' {
+		d.neg, s = true, s[1:]
+	}
+
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return decimal{}, false
+		}
+
+		d.exp, s = int(exp), s[:i]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	d.exp += len(intPart)
+
+	trimmed := strings.TrimLeft(digits, "0")
+	d.exp -= len(digits) - len(trimmed)
+	d.digits = strings.TrimRight(trimmed, "0")
+
+	if d.digits == "" {
+		return decimal{}, true // zero, regardless of sign and exponent
+	}
+
+	return d, true
+}
+
+// sign returns -1, 0 or +1 depending on whether the decimal is negative, zero or positive.
+func (d decimal) sign() int {
+	switch {
+	case d.digits == "":
+		return 0
+	case d.neg:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// cmp compares two decimals by their value.
+func (d decimal) cmp(e decimal) int {
+	if c := d.sign() - e.sign(); c != 0 {
+		return max(-1, min(c, 1))
+	}
+
+	c := 0
+	switch {
+	case d.sign() == 0:
+	case d.exp != e.exp:
+		c = cmpInt(d.exp, e.exp)
+	default:
+		// without trailing zeros, a shorter prefix is the smaller value
+		c = strings.Compare(d.digits, e.digits)
+	}
+
+	if d.neg {
+		return -c
+	}
+
+	return c
+}
+
+// isMultipleOf reports whether dividing the decimal by a positive decimal e results in an integer.
+func (d decimal) isMultipleOf(e decimal) bool {
+	if d.digits == "" {
+		return true
+	}
+
+	num, _ := new(big.Int).SetString(d.digits, 10)
+	den, _ := new(big.Int).SetString(e.digits, 10)
+
+	// the quotient is num / den times 10 to the power of k
+	k := (d.exp - len(d.digits)) - (e.exp - len(e.digits))
+	if k < 0 {
+		if -k >= len(d.digits) {
+			return false // the divisor is larger than the number
+		}
+
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-k)), nil))
+	} else {
+		// beyond the factors 2 and 5 of the divisor, further powers of 10 don't change divisibility
+		k = min(k, 4*len(e.digits))
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(k)), nil))
+	}
+
+	return new(big.Int).Rem(num, den).Sign() == 0
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var _ json.MarshalerTo = (*Number)(nil)
+
+// MarshalJSONTo writes the number as is.
+func (n *Number) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteValue(jsontext.Value(*n))
+}
+
+var _ json.UnmarshalerFrom = (*Number)(nil)
+
+// UnmarshalJSONFrom reads the text of a JSON number.
+func (n *Number) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if kind := dec.PeekKind(); kind != jsontext.KindNumber {
+		return fmt.Errorf("expected number, got %s", kind)
+	}
+
+	v, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	*n = Number(v)
+	return nil
+}
@@ -0,0 +1,107 @@
+package openapi_test
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestNumber(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		a, b       openapi.Number
+		cmp        int
+		isInteger  bool
+		isMultiple bool
+	}{
+		{"1", "1.0", 0, true, true},
+		{"1e2", "100", 0, true, true},
+		{"9007199254740993", "9007199254740992", 1, true, false},
+		{"-9223372036854775808", "9223372036854775807", -1, true, false},
+		{"0.3", "0.1", 1, false, true},
+		{"19.99", "0.01", 1, false, true},
+		{"0.1", "0.3", -1, false, false},
+		{"1.5e-3", "0.0005", 1, false, true},
+		{"1e400", "1e399", 1, true, true},
+		{"1e100000", "1e99999", 1, true, true},
+		{"1e2000", "1e-2000", 1, true, true},
+		{"1e2000", "3", 1, true, false},
+		{"6e2000", "3", 1, true, true},
+		{"1e-2000", "1", -1, false, false},
+		{"1e-2000", "0", 1, false, false},
+		{"1e-2000", "1e-2001", 1, false, true},
+		{"-1e-2000", "-2e-2000", 1, false, false},
+		{"12345e-2000", "1234.5e-1999", 0, false, true},
+		{"1000e-3", "1", 0, true, true},
+		{"-0.0e-5000", "0", 0, true, false},
+	} {
+		t.Run(tc.a.String()+" "+tc.b.String(), func(t *testing.T) {
+			if got := tc.a.Cmp(tc.b); got != tc.cmp {
+				t.Fatalf("Cmp: want %d, got %d", tc.cmp, got)
+			}
+
+			if got := tc.a.IsInteger(); got != tc.isInteger {
+				t.Fatalf("IsInteger: want %t, got %t", tc.isInteger, got)
+			}
+
+			if got := tc.a.IsMultipleOf(tc.b); got != tc.isMultiple {
+				t.Fatalf("IsMultipleOf: want %t, got %t", tc.isMultiple, got)
+			}
+		})
+	}
+}
+
+func TestNumber_Sign(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		n    openapi.Number
+		want int
+	}{
+		{"0", 0},
+		{"-0.0", 0},
+		{"0e-2000", 0},
+		{"1e-2000", 1},
+		{"-1e-2000", -1},
+		{"1e2000", 1},
+		{"-3.5", -1},
+	} {
+		t.Run(tc.n.String(), func(t *testing.T) {
+			if got := tc.n.Sign(); got != tc.want {
+				t.Fatalf("want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNumber_JSON(t *testing.T) {
+	t.Parallel()
+
+	// numbers of schemas round-trip byte for byte
+	const data = `{"type":"integer","format":"int64","minimum":-9223372036854775808,"maximum":9223372036854775807,` +
+		`"exclusiveMinimum":0.10000000000000000001,"multipleOf":1E-2,"enum":[9007199254740993,1.10]}`
+
+	s := &openapi.Schema{}
+	if err := json.Unmarshal([]byte(data), s); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := openapi.Number("9223372036854775807"); *s.Max != want {
+		t.Fatalf("want: %s, got: %s", want, *s.Max)
+	}
+
+	out, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(out) != data {
+		t.Fatalf("want: %s, got: %s", data, out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"minimum":"1"}`), s); err == nil {
+		t.Fatal("expected an error for a string")
+	}
+}
@@ -16,6 +16,7 @@ func TestOrderedMaps(t *testing.T) {
 	testSort[*openapi.Headers](t)
 	testSort[*openapi.LinkParameters](t)
 	testSort[*openapi.Links](t)
+	testSort2[*openapi.MapOfStringLists](t)
 	testSort2[*openapi.MapOfStrings](t)
 	testSort[*openapi.Parameters](t)
 	testSort[*openapi.PathItems](t)
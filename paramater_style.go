@@ -28,6 +28,9 @@ import (
 //	| spaceDelimited | false     | n/a     | n/a         | blue%20black%20brown                | R%20100%20G%20200%20B%20150            |
 //	| pipeDelimited  | false     | n/a     | n/a         | blue|black|brown                    | R|100|G|200|B|150                      |
 //	| deepObject     | true      | n/a     | n/a         | n/a                                 | color[R]=100&color[G]=200&color[B]=150 |
+//
+// Parameter.Serialize and Header.Serialize implement these rules.
+// In a query string, the values of `spaceDelimited` and `pipeDelimited` are prefixed with the name, e.g. `color=blue|black|brown`.
 type ParameterStyle string
 
 const (
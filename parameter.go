@@ -129,14 +129,14 @@ func (p *Parameter) Validate() error {
 			return &errpath.ErrField{Field: "style", Err: err}
 		}
 	} else if p.In == ParameterLocationQuery && p.Schema != nil &&
-		(p.Schema.Type == TypeArray || p.Schema.Type == TypeObject) {
+		(p.Schema.Type.Includes(TypeArray) || p.Schema.Type.Includes(TypeObject)) {
 		// Form style is the default for query parameters in OpenAPI 3.0+, regardless of whether the parameter is a primitive, array, or object (when style is omitted).
 		// We set the default explicitly, but just for array and object (to not clutter the specification) to make things clearer.
 		p.Style = ParameterStyleForm
 	}
 
 	arrayOrObject := p.Schema != nil &&
-		(p.Schema.Type == TypeArray || p.Schema.Type == TypeObject)
+		(p.Schema.Type.Includes(TypeArray) || p.Schema.Type.Includes(TypeObject))
 	if p.Explode != nil {
 		if p.Schema == nil {
 			return &errpath.ErrField{Field: "explode", Err: &errpath.ErrInvalid[bool]{
@@ -179,7 +179,7 @@ func (l *loader) collectParameter(p *Parameter, ref ref) {
 }
 
 func (l *loader) resolveParameterRef(p *ParameterRef) error {
-	return resolveRef(p, l.parameters, l.resolveParameter)
+	return resolveRef(l, p, l.parameters, l.resolveParameter)
 }
 
 func (l *loader) resolveParameter(p *Parameter) error {
@@ -75,6 +75,44 @@ func (p ParameterList) InHeader() ParameterList {
 	return p.In(ParameterLocationHeader)
 }
 
+// override returns the parameters, each replaced by the parameter of the overrides with the same name and location,
+// followed by the remaining overrides. Parameters that are not resolved are never replaced.
+func (p ParameterList) override(overrides ParameterList) ParameterList {
+	if len(overrides) == 0 {
+		return p
+	}
+
+	byID := make(map[parameterID]*ParameterRef, len(overrides))
+	for _, param := range overrides {
+		if param.Value != nil {
+			byID[parameterID{Name: param.Value.Name, Location: param.Value.In}] = param
+		}
+	}
+
+	result := make(ParameterList, 0, len(p)+len(overrides))
+	replaced := make(map[*ParameterRef]bool, len(overrides))
+	for _, param := range p {
+		if param.Value != nil {
+			if o, ok := byID[parameterID{Name: param.Value.Name, Location: param.Value.In}]; ok {
+				result = append(result, o)
+				replaced[o] = true
+
+				continue
+			}
+		}
+
+		result = append(result, param)
+	}
+
+	for _, param := range overrides {
+		if !replaced[param] {
+			result = append(result, param)
+		}
+	}
+
+	return result
+}
+
 func (l *loader) resolveParameterList(p ParameterList) error {
 	for i, param := range p {
 		if err := l.resolveParameterRef(param); err != nil {
@@ -0,0 +1,588 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+var (
+	// ErrParameterMissing is returned when deserializing a query or cookie parameter that is not present.
+	ErrParameterMissing = errors.New("parameter is missing")
+	// ErrEmptyValue is returned when serializing or deserializing an empty query parameter that does not allow empty values.
+	ErrEmptyValue = errors.New("empty value is not allowed")
+)
+
+// Serialize serializes a value according to the style of the parameter, see ParameterStyle.
+//
+// The value is first converted to JSON, so it may be a primitive, a slice, a map or a struct.
+// Arrays and objects must only contain primitives. The result is:
+//   - for path parameters, the text that replaces the template expression, e.g. `;color=blue`,
+//   - for query parameters, the part of the query string, e.g. `color=blue&color=black`,
+//   - for header parameters, the header value, e.g. `blue,black`,
+//   - for cookie parameters, the cookie pair, e.g. `color=blue`.
+//
+// Values are percent-encoded, except for headers and reserved characters of query parameters that allow them.
+func (p *Parameter) Serialize(v any) (string, error) {
+	z, err := p.serialization()
+	if err != nil {
+		return "", err
+	}
+
+	return z.serialize(v)
+}
+
+// Deserialize deserializes a value serialized according to the style of the parameter, see Serialize.
+// For query and cookie parameters, it takes the whole query string or Cookie header, respectively.
+//
+// The value has the form produced by unmarshaling JSON into an `any`, except that numbers are of type Number,
+// as determined by the type of the schema of the parameter and its items or properties.
+func (p *Parameter) Deserialize(s string) (any, error) {
+	z, err := p.serialization()
+	if err != nil {
+		return nil, err
+	}
+
+	return z.deserialize(s)
+}
+
+// Serialize serializes a value according to the `simple` style, see Parameter.Serialize.
+func (h *Header) Serialize(v any) (string, error) {
+	z, err := h.serialization()
+	if err != nil {
+		return "", err
+	}
+
+	return z.serialize(v)
+}
+
+// Deserialize deserializes a header value serialized according to the `simple` style, see Parameter.Deserialize.
+func (h *Header) Deserialize(s string) (any, error) {
+	z, err := h.serialization()
+	if err != nil {
+		return nil, err
+	}
+
+	return z.deserialize(s)
+}
+
+// serialization holds the rules for serializing a parameter or header, with defaults applied.
+type serialization struct {
+	name            string
+	in              ParameterLocation
+	style           ParameterStyle
+	explode         bool
+	allowReserved   bool
+	allowEmptyValue bool
+	schema          *Schema
+}
+
+func (p *Parameter) serialization() (*serialization, error) {
+	if p.Schema == nil {
+		return nil, errors.New("only parameters with a schema can be serialized by style")
+	}
+
+	z := &serialization{
+		name:            p.Name,
+		in:              p.In,
+		style:           p.Style,
+		allowReserved:   p.AllowReserved,
+		allowEmptyValue: p.AllowEmptyValue,
+		schema:          p.Schema,
+	}
+
+	if z.style == "" {
+		switch p.In {
+		case ParameterLocationQuery, ParameterLocationCookie:
+			z.style = ParameterStyleForm
+		default:
+			z.style = ParameterStyleSimple
+		}
+	}
+
+	z.explode = z.style == ParameterStyleForm || z.style == ParameterStyleDeepObject
+	if p.Explode != nil {
+		z.explode = *p.Explode
+	}
+
+	return z, nil
+}
+
+func (h *Header) serialization() (*serialization, error) {
+	if h.Schema == nil {
+		return nil, errors.New("only headers with a schema can be serialized by style")
+	}
+
+	z := &serialization{in: ParameterLocationHeader, style: ParameterStyleSimple, schema: h.Schema}
+	if h.Explode != nil {
+		z.explode = *h.Explode
+	}
+
+	return z, nil
+}
+
+// paramValue is a value in the form that can be serialized: a primitive, an array of primitives or an object with primitive values.
+type paramValue struct {
+	kind  jsontext.Kind
+	text  string          // the text of a primitive, empty for null
+	items []string        // the texts of the items of an array
+	props []paramProperty // the properties of an object, in order
+}
+
+type paramProperty struct {
+	name, value string
+}
+
+func (v *paramValue) isEmpty() bool {
+	switch v.kind {
+	case jsontext.KindBeginArray:
+		return len(v.items) == 0
+	case jsontext.KindBeginObject:
+		return len(v.props) == 0
+	default:
+		return v.text == ""
+	}
+}
+
+// toParamValue converts a value to its JSON form. The properties of maps are sorted, those of structs keep their order.
+func toParamValue(v any) (*paramValue, error) {
+	data, err := json.Marshal(v, json.Deterministic(true))
+	if err != nil {
+		return nil, err
+	}
+
+	dec := jsontext.NewDecoder(bytes.NewReader(data))
+
+	pv := &paramValue{kind: dec.PeekKind()}
+	switch pv.kind {
+	case jsontext.KindBeginArray:
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+
+		for dec.PeekKind() != jsontext.KindEndArray {
+			text, err := readParamPrimitive(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			pv.items = append(pv.items, text)
+		}
+	case jsontext.KindBeginObject:
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+
+		for dec.PeekKind() != jsontext.KindEndObject {
+			tok, err := dec.ReadToken()
+			if err != nil {
+				return nil, err
+			}
+
+			name := tok.String() // the token is only valid until the next read
+
+			text, err := readParamPrimitive(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			pv.props = append(pv.props, paramProperty{name, text})
+		}
+	default:
+		if pv.text, err = readParamPrimitive(dec); err != nil {
+			return nil, err
+		}
+	}
+
+	return pv, nil
+}
+
+func readParamPrimitive(dec *jsontext.Decoder) (string, error) {
+	switch kind := dec.PeekKind(); kind {
+	case jsontext.KindBeginArray, jsontext.KindBeginObject:
+		return "", errors.New("nested arrays and objects can't be serialized by style")
+	case jsontext.KindNull:
+		_, err := dec.ReadToken()
+		return "", err
+	default:
+		tok, err := dec.ReadToken()
+		return tok.String(), err
+	}
+}
+
+// escape percent-encodes a name or value, depending on the location.
+func (z *serialization) escape(s string) string {
+	switch {
+	case z.in == ParameterLocationHeader:
+		return s
+	case z.allowReserved:
+		return escapeReserved(s)
+	default:
+		return escapeTemplateValue(s)
+	}
+}
+
+// escapeReserved percent-encodes all characters except the unreserved and reserved ones of RFC 3986.
+func escapeReserved(s string) string {
+	b := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(":/?#[]@!$&'()*+,;=", s[i]) >= 0 {
+			b.WriteByte(s[i])
+		} else {
+			b.WriteString(escapeTemplateValue(s[i : i+1]))
+		}
+	}
+
+	return b.String()
+}
+
+func (z *serialization) serialize(v any) (string, error) {
+	pv, err := toParamValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	if z.in == ParameterLocationQuery && !z.allowEmptyValue && pv.isEmpty() {
+		return "", ErrEmptyValue
+	}
+
+	name := z.escape(z.name)
+
+	// the escaped items of an array, or the escaped names and values of an object, alternating
+	var values []string
+	switch pv.kind {
+	case jsontext.KindBeginArray:
+		for _, item := range pv.items {
+			values = append(values, z.escape(item))
+		}
+	case jsontext.KindBeginObject:
+		for _, p := range pv.props {
+			values = append(values, z.escape(p.name), z.escape(p.value))
+		}
+	}
+
+	// joins the values, separating names and values of an object by `=` if exploded
+	join := func(sep string) string {
+		if pv.kind != jsontext.KindBeginObject || !z.explode {
+			return strings.Join(values, sep)
+		}
+
+		pairs := make([]string, 0, len(values)/2)
+		for i := 0; i < len(values); i += 2 {
+			pairs = append(pairs, values[i]+"="+values[i+1])
+		}
+
+		return strings.Join(pairs, sep)
+	}
+
+	primitive := pv.kind != jsontext.KindBeginArray && pv.kind != jsontext.KindBeginObject
+
+	switch z.style {
+	case ParameterStyleMatrix:
+		switch {
+		case pv.isEmpty():
+			return ";" + name, nil
+		case primitive:
+			return ";" + name + "=" + z.escape(pv.text), nil
+		case !z.explode:
+			return ";" + name + "=" + join(","), nil
+		case pv.kind == jsontext.KindBeginArray:
+			return ";" + name + "=" + strings.Join(values, ";"+name+"="), nil
+		default:
+			return ";" + join(";"), nil
+		}
+	case ParameterStyleLabel:
+		if primitive {
+			return "." + z.escape(pv.text), nil
+		}
+
+		return "." + join("."), nil
+	case ParameterStyleSimple:
+		if primitive {
+			return z.escape(pv.text), nil
+		}
+
+		return join(","), nil
+	case ParameterStyleForm, ParameterStyleSpaceDelimited, ParameterStylePipeDelimited:
+		sep := map[ParameterStyle]string{
+			ParameterStyleForm:           ",",
+			ParameterStyleSpaceDelimited: "%20",
+			ParameterStylePipeDelimited:  "|",
+		}[z.style]
+
+		switch {
+		case primitive && z.style != ParameterStyleForm:
+			return "", fmt.Errorf("style %s does not apply to primitive values", z.style)
+		case primitive:
+			return name + "=" + z.escape(pv.text), nil
+		case !z.explode || pv.isEmpty():
+			return name + "=" + join(sep), nil
+		case pv.kind == jsontext.KindBeginArray:
+			return name + "=" + strings.Join(values, "&"+name+"="), nil
+		default:
+			return join("&"), nil
+		}
+	case ParameterStyleDeepObject:
+		if pv.kind != jsontext.KindBeginObject {
+			return "", fmt.Errorf("style %s only applies to objects", z.style)
+		}
+
+		pairs := make([]string, 0, len(values)/2)
+		for i := 0; i < len(values); i += 2 {
+			pairs = append(pairs, name+"["+values[i]+"]="+values[i+1])
+		}
+
+		return strings.Join(pairs, "&"), nil
+	default:
+		return "", fmt.Errorf("unknown style %q", z.style)
+	}
+}
+
+func (z *serialization) deserialize(s string) (any, error) {
+	var (
+		// the unescaped text of a primitive, the items of an array, or the names and values of an object
+		texts []string
+		// whether the texts were given as pairs of names and values
+		pairs bool
+		err   error
+	)
+
+	switch z.style {
+	case ParameterStyleMatrix, ParameterStyleLabel, ParameterStyleSimple:
+		texts, pairs, err = z.splitPath(s)
+	default:
+		texts, pairs, err = z.splitQuery(s)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case z.schema.Type.Includes(TypeArray):
+		items := make([]any, len(texts))
+		for i, text := range texts {
+			if items[i], err = decodeParamText(text, z.schema.Items); err != nil {
+				return nil, &errpath.ErrIndex{Index: i, Err: err}
+			}
+		}
+
+		return items, nil
+	case z.schema.Type.Includes(TypeObject):
+		if !pairs && len(texts)%2 != 0 {
+			return nil, fmt.Errorf("expected names and values, got %d parts", len(texts))
+		}
+
+		obj := make(map[string]any, len(texts)/2)
+		for i := 0; i+1 < len(texts); i += 2 {
+			name := texts[i]
+
+			prop := z.schema.AdditionalProperties
+			if p, ok := z.schema.Properties[name]; ok {
+				prop = p
+			}
+
+			if obj[name], err = decodeParamText(texts[i+1], prop); err != nil {
+				return nil, &errpath.ErrKey{Key: name, Err: err}
+			}
+		}
+
+		return obj, nil
+	default:
+		text := strings.Join(texts, ",")
+		return decodeParamText(text, &SchemaRef{Value: z.schema})
+	}
+}
+
+// splitPath splits the text of a path parameter or header into its unescaped parts.
+// It reports whether the parts are pairs of names and values.
+func (z *serialization) splitPath(s string) ([]string, bool, error) {
+	unescape := url.PathUnescape
+	if z.in == ParameterLocationHeader {
+		unescape = func(s string) (string, error) { return s, nil }
+	}
+
+	objectExplode := z.explode && z.schema.Type.Includes(TypeObject)
+
+	var parts []string
+	switch z.style {
+	case ParameterStyleMatrix:
+		if !strings.HasPrefix(s, ";") {
+			return nil, false, fmt.Errorf("expected %q to start with ;", s)
+		}
+
+		for _, part := range strings.Split(s[1:], ";") {
+			name, value, ok := strings.Cut(part, "=")
+			switch {
+			case objectExplode:
+				parts = append(parts, part)
+			case name != z.name:
+				return nil, false, fmt.Errorf("unexpected parameter %q", name)
+			case !ok: // empty
+			case z.explode:
+				parts = append(parts, value)
+			default:
+				parts = append(parts, z.split(value, ",")...)
+			}
+		}
+	case ParameterStyleLabel:
+		if !strings.HasPrefix(s, ".") {
+			return nil, false, fmt.Errorf("expected %q to start with .", s)
+		}
+
+		parts = z.split(s[1:], ".")
+	default:
+		parts = z.split(s, ",")
+	}
+
+	return unescapeParts(parts, objectExplode, unescape)
+}
+
+var (
+	reSpaceDelimiter = regexp.MustCompile(`%20|\+| `)
+	rePipeDelimiter  = regexp.MustCompile(`\||%7[cC]`)
+)
+
+// splitQuery finds a query or cookie parameter in the query string or Cookie header and splits it into its unescaped parts.
+// It reports whether the parts are pairs of names and values.
+func (z *serialization) splitQuery(s string) ([]string, bool, error) {
+	objectExplode := z.explode && z.schema.Type.Includes(TypeObject)
+
+	var (
+		parts []string
+		found bool
+	)
+
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '&' || (z.in == ParameterLocationCookie && r == ';') }) {
+		rawName, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch {
+		case z.style == ParameterStyleDeepObject:
+			if prop, ok := strings.CutPrefix(name, z.name+"["); ok && strings.HasSuffix(prop, "]") {
+				parts = append(parts, url.QueryEscape(strings.TrimSuffix(prop, "]"))+"="+value)
+				found = true
+			}
+		case objectExplode:
+			if _, ok := z.schema.Properties[name]; ok || len(z.schema.Properties) == 0 {
+				parts = append(parts, rawName+"="+value)
+				found = true
+			}
+		case name != z.name:
+		case value == "": // empty
+			found = true
+		case z.explode && z.schema.Type.Includes(TypeArray):
+			parts = append(parts, value)
+			found = true
+		case z.style == ParameterStyleSpaceDelimited && z.isComposite():
+			parts = append(parts, reSpaceDelimiter.Split(value, -1)...)
+			found = true
+		case z.style == ParameterStylePipeDelimited && z.isComposite():
+			parts = append(parts, rePipeDelimiter.Split(value, -1)...)
+			found = true
+		default:
+			parts = append(parts, z.split(value, ",")...)
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, false, ErrParameterMissing
+	}
+
+	if !z.allowEmptyValue && z.in == ParameterLocationQuery && len(parts) == 0 {
+		return nil, false, ErrEmptyValue
+	}
+
+	return unescapeParts(parts, objectExplode || z.style == ParameterStyleDeepObject, url.QueryUnescape)
+}
+
+// isComposite reports whether the schema describes an array or object.
+func (z *serialization) isComposite() bool {
+	return z.schema.Type.Includes(TypeArray) || z.schema.Type.Includes(TypeObject)
+}
+
+// split splits the text of an array or object by the separator. The text of a primitive is not split.
+func (z *serialization) split(s, sep string) []string {
+	switch {
+	case !z.isComposite():
+		return []string{s}
+	case s == "":
+		return nil
+	default:
+		return strings.Split(s, sep)
+	}
+}
+
+// unescapeParts unescapes the parts, splitting each one into name and value if they are pairs.
+func unescapeParts(parts []string, pairs bool, unescape func(string) (string, error)) ([]string, bool, error) {
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if !pairs {
+			text, err := unescape(part)
+			if err != nil {
+				return nil, false, err
+			}
+
+			texts = append(texts, text)
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, false, fmt.Errorf("expected name=value, got %q", part)
+		}
+
+		name, err := unescape(name)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if value, err = unescape(value); err != nil {
+			return nil, false, err
+		}
+
+		texts = append(texts, name, value)
+	}
+
+	return texts, pairs, nil
+}
+
+// decodeParamText decodes the text of a primitive according to the type of the schema.
+// Without a schema or type, the text is returned as string.
+func decodeParamText(text string, r *SchemaRef) (any, error) {
+	if r == nil || r.Value == nil || r.Value.Type.IsZero() {
+		return text, nil
+	}
+
+	s := r.Value
+	if text == "" && s.IsNullable() {
+		return nil, nil
+	}
+
+	if s.Type.Includes(TypeNumber) || s.Type.Includes(TypeInteger) {
+		if v := jsontext.Value(text); v.Kind() == jsontext.KindNumber && v.IsValid() {
+			return Number(text), nil
+		}
+	}
+
+	if s.Type.Includes(TypeBoolean) && (text == "true" || text == "false") {
+		return text == "true", nil
+	}
+
+	if s.Type.Includes(TypeString) {
+		return text, nil
+	}
+
+	return nil, fmt.Errorf("cannot decode %q as %s", text, s.Type)
+}
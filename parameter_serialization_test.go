@@ -0,0 +1,222 @@
+package openapi_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+type color struct {
+	R int `json:"R"`
+	G int `json:"G"`
+	B int `json:"B"`
+}
+
+var (
+	colorString = &openapi.Schema{Type: openapi.Types(openapi.TypeString)}
+	colorArray  = &openapi.Schema{
+		Type:  openapi.Types(openapi.TypeArray),
+		Items: &openapi.SchemaRef{Value: colorString},
+	}
+	colorObject = &openapi.Schema{
+		Type: openapi.Types(openapi.TypeObject),
+		Properties: openapi.SchemaRefs{
+			"R": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeInteger)}},
+			"G": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeInteger)}},
+			"B": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeInteger)}},
+		},
+	}
+)
+
+// TestParameter_Serialize follows the style examples of the specification, see ParameterStyle.
+func TestParameter_Serialize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		str    = "blue"
+		arr    = []string{"blue", "black", "brown"}
+		obj    = color{R: 100, G: 200, B: 150}
+		strVal = any("blue")
+		arrVal = any([]any{"blue", "black", "brown"})
+		objVal = any(map[string]any{"R": openapi.Number("100"), "G": openapi.Number("200"), "B": openapi.Number("150")})
+	)
+
+	for _, tc := range []struct {
+		style   openapi.ParameterStyle
+		explode bool
+		value   any
+		schema  *openapi.Schema
+		want    string
+		decoded any
+	}{
+		{openapi.ParameterStyleMatrix, false, "", colorString, ";color", ""},
+		{openapi.ParameterStyleMatrix, false, str, colorString, ";color=blue", strVal},
+		{openapi.ParameterStyleMatrix, false, arr, colorArray, ";color=blue,black,brown", arrVal},
+		{openapi.ParameterStyleMatrix, false, obj, colorObject, ";color=R,100,G,200,B,150", objVal},
+		{openapi.ParameterStyleMatrix, true, []string{}, colorArray, ";color", []any{}},
+		{openapi.ParameterStyleMatrix, true, str, colorString, ";color=blue", strVal},
+		{openapi.ParameterStyleMatrix, true, arr, colorArray, ";color=blue;color=black;color=brown", arrVal},
+		{openapi.ParameterStyleMatrix, true, obj, colorObject, ";R=100;G=200;B=150", objVal},
+		{openapi.ParameterStyleLabel, false, "", colorString, ".", ""},
+		{openapi.ParameterStyleLabel, false, str, colorString, ".blue", strVal},
+		{openapi.ParameterStyleLabel, false, arr, colorArray, ".blue.black.brown", arrVal},
+		{openapi.ParameterStyleLabel, false, obj, colorObject, ".R.100.G.200.B.150", objVal},
+		{openapi.ParameterStyleLabel, true, []string{}, colorArray, ".", []any{}},
+		{openapi.ParameterStyleLabel, true, str, colorString, ".blue", strVal},
+		{openapi.ParameterStyleLabel, true, arr, colorArray, ".blue.black.brown", arrVal},
+		{openapi.ParameterStyleLabel, true, obj, colorObject, ".R=100.G=200.B=150", objVal},
+		{openapi.ParameterStyleForm, false, "", colorString, "color=", ""},
+		{openapi.ParameterStyleForm, false, str, colorString, "color=blue", strVal},
+		{openapi.ParameterStyleForm, false, arr, colorArray, "color=blue,black,brown", arrVal},
+		{openapi.ParameterStyleForm, false, obj, colorObject, "color=R,100,G,200,B,150", objVal},
+		{openapi.ParameterStyleForm, true, []string{}, colorArray, "color=", []any{}},
+		{openapi.ParameterStyleForm, true, str, colorString, "color=blue", strVal},
+		{openapi.ParameterStyleForm, true, arr, colorArray, "color=blue&color=black&color=brown", arrVal},
+		{openapi.ParameterStyleForm, true, obj, colorObject, "R=100&G=200&B=150", objVal},
+		{openapi.ParameterStyleSimple, false, str, colorString, "blue", strVal},
+		{openapi.ParameterStyleSimple, false, arr, colorArray, "blue,black,brown", arrVal},
+		{openapi.ParameterStyleSimple, false, obj, colorObject, "R,100,G,200,B,150", objVal},
+		{openapi.ParameterStyleSimple, true, str, colorString, "blue", strVal},
+		{openapi.ParameterStyleSimple, true, arr, colorArray, "blue,black,brown", arrVal},
+		{openapi.ParameterStyleSimple, true, obj, colorObject, "R=100,G=200,B=150", objVal},
+		{openapi.ParameterStyleSpaceDelimited, false, arr, colorArray, "color=blue%20black%20brown", arrVal},
+		{openapi.ParameterStyleSpaceDelimited, false, obj, colorObject, "color=R%20100%20G%20200%20B%20150", objVal},
+		{openapi.ParameterStylePipeDelimited, false, arr, colorArray, "color=blue|black|brown", arrVal},
+		{openapi.ParameterStylePipeDelimited, false, obj, colorObject, "color=R|100|G|200|B|150", objVal},
+		{openapi.ParameterStyleDeepObject, true, obj, colorObject, "color[R]=100&color[G]=200&color[B]=150", objVal},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			p := &openapi.Parameter{
+				Name:            "color",
+				In:              openapi.ParameterLocationQuery,
+				AllowEmptyValue: true,
+				Style:           tc.style,
+				Explode:         &tc.explode,
+				Schema:          tc.schema,
+			}
+
+			switch tc.style {
+			case openapi.ParameterStyleMatrix, openapi.ParameterStyleLabel, openapi.ParameterStyleSimple:
+				p.In, p.AllowEmptyValue = openapi.ParameterLocationPath, false
+			}
+
+			got, err := p.Serialize(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("want: %s, got: %s", tc.want, got)
+			}
+
+			if p.In == openapi.ParameterLocationQuery && tc.schema != colorObject {
+				got = "other=1&" + got // other parameters are ignored
+			}
+
+			decoded, err := p.Deserialize(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(decoded, tc.decoded) {
+				t.Fatalf("want: %#v, got: %#v", tc.decoded, decoded)
+			}
+		})
+	}
+}
+
+func TestParameter_Serialize_Escaping(t *testing.T) {
+	t.Parallel()
+
+	p := &openapi.Parameter{Name: "q", In: openapi.ParameterLocationQuery, Schema: colorArray}
+
+	got, err := p.Serialize([]string{"a b", "c&d", "é/,"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "q=a%20b&q=c%26d&q=%C3%A9%2F%2C"; got != want {
+		t.Fatalf("want: %s, got: %s", want, got)
+	}
+
+	p.AllowReserved = true
+	if got, err = p.Serialize("a/b?c d"); err != nil {
+		t.Fatal(err)
+	} else if want := "q=a/b?c%20d"; got != want {
+		t.Fatalf("want: %s, got: %s", want, got)
+	}
+
+	// the value of a header is not percent-encoded
+	h := &openapi.Header{Schema: colorArray}
+	if got, err = h.Serialize([]string{"a b", "c/d"}); err != nil {
+		t.Fatal(err)
+	} else if want := "a b,c/d"; got != want {
+		t.Fatalf("want: %s, got: %s", want, got)
+	}
+
+	decoded, err := h.Deserialize(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []any{"a b", "c/d"}; !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("want: %v, got: %v", want, decoded)
+	}
+}
+
+func TestParameter_Serialize_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		param *openapi.Parameter
+		value any
+		err   string
+	}{
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationQuery, Schema: colorString}, "", "empty value is not allowed"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationQuery, Style: openapi.ParameterStyleDeepObject, Schema: colorArray}, []string{"a"}, "style deepObject only applies to objects"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationQuery, Style: openapi.ParameterStylePipeDelimited, Schema: colorString}, "a", "style pipeDelimited does not apply to primitive values"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationPath, Schema: colorArray}, [][]string{{"a"}}, "nested arrays and objects can't be serialized by style"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationPath}, "a", "only parameters with a schema can be serialized by style"},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			if _, err := tc.param.Serialize(tc.value); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestParameter_Deserialize_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		param *openapi.Parameter
+		data  string
+		err   string
+	}{
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationQuery, Schema: colorString}, "other=1", "parameter is missing"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationQuery, Schema: colorString}, "color=", "empty value is not allowed"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationPath, Style: openapi.ParameterStyleMatrix, Schema: colorString}, "blue", `expected "blue" to start with ;`},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationPath, Style: openapi.ParameterStyleMatrix, Schema: colorString}, ";size=1", `unexpected parameter "size"`},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationPath, Schema: colorObject}, "R,100,G", "expected names and values, got 3 parts"},
+		{&openapi.Parameter{Name: "color", In: openapi.ParameterLocationPath, Schema: colorObject}, "R,red", `["R"]: cannot decode "red" as integer`},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			_, err := tc.param.Deserialize(tc.data)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+
+	p := &openapi.Parameter{Name: "id", In: openapi.ParameterLocationCookie, Schema: colorString}
+	if v, err := p.Deserialize("session=abc; id=42"); err != nil || v != "42" {
+		t.Fatalf("want: 42, got: %v, %v", v, err)
+	}
+
+	if _, err := p.Deserialize("session=abc"); !errors.Is(err, openapi.ErrParameterMissing) {
+		t.Fatalf("want: %v, got: %v", openapi.ErrParameterMissing, err)
+	}
+}
@@ -126,21 +126,21 @@ func TestParameter_Validate_Error(t *testing.T) {
 			Name:            "myname",
 			In:              openapi.ParameterLocationPath,
 			Required:        true,
-			Schema:          &openapi.Schema{Type: openapi.TypeString},
+			Schema:          &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			AllowEmptyValue: true,
 		}, `allowEmptyValue (true) is invalid: can only be true for query parameters, got "path"`},
 		{openapi.Parameter{
 			Name:          "myname",
 			In:            openapi.ParameterLocationPath,
 			Required:      true,
-			Schema:        &openapi.Schema{Type: openapi.TypeString},
+			Schema:        &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			AllowReserved: true,
 		}, `allowReserved (true) is invalid: only applies to query parameters, got "path"`},
 		{openapi.Parameter{
 			Name:     "myname",
 			In:       openapi.ParameterLocationPath,
 			Required: true,
-			Schema:   &openapi.Schema{Type: openapi.TypeString},
+			Schema:   &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Content:  openapi.Content{},
 		}, `schema and content are mutually exclusive`},
 		{openapi.Parameter{
@@ -173,14 +173,14 @@ func TestParameter_Validate_Error(t *testing.T) {
 			Name:     "myname",
 			In:       openapi.ParameterLocationPath,
 			Required: true,
-			Schema:   &openapi.Schema{Type: openapi.TypeString},
+			Schema:   &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Explode:  yes,
 		}, `explode (true) is invalid: property has no effect when schema type is not array or object, got "string"`},
 		{openapi.Parameter{
 			Name:     "myname",
 			In:       openapi.ParameterLocationPath,
 			Required: true,
-			Schema:   &openapi.Schema{Type: openapi.TypeString},
+			Schema:   &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Example:  jsontext.Value("foo"),
 			Examples: openapi.Examples{},
 		}, `example and examples are mutually exclusive`},
@@ -188,13 +188,13 @@ func TestParameter_Validate_Error(t *testing.T) {
 			Name:       "myname",
 			In:         openapi.ParameterLocationPath,
 			Required:   true,
-			Schema:     &openapi.Schema{Type: openapi.TypeString},
+			Schema:     &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Extensions: []byte(`{"foo": "bar"}`),
 		}, `foo: ` + openapi.ErrUnknownField.Error()},
 		{openapi.Parameter{
 			Name:   "myname",
 			In:     openapi.ParameterLocationQuery,
-			Schema: &openapi.Schema{Type: openapi.TypeString},
+			Schema: &openapi.Schema{Type: openapi.Types(openapi.TypeString)},
 			Examples: openapi.Examples{
 				"foo": invalidExample,
 			},
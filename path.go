@@ -1,6 +1,10 @@
 package openapi
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Path represents a URL path template.
 //
@@ -24,5 +28,51 @@ func (p Path) Validate() error {
 		return errors.New("path must start with a /")
 	}
 
+	if i := strings.IndexAny(string(p), "?#"); i >= 0 {
+		return fmt.Errorf("path must not contain a query string or fragment, found %q", p[i])
+	}
+
+	return p.validateTemplate()
+}
+
+// validateTemplate checks that template expressions are well-formed and that variable names are unique.
+func (p Path) validateTemplate() error {
+	names := map[string]bool{}
+	start := -1
+
+	for i, c := range p {
+		switch c {
+		case '{':
+			if start >= 0 {
+				return fmt.Errorf("unbalanced braces: unexpected { at position %d", i)
+			}
+
+			start = i
+		case '}':
+			switch {
+			case start < 0:
+				return fmt.Errorf("unbalanced braces: unexpected } at position %d", i)
+			case start == i-1:
+				return fmt.Errorf("empty template expression at position %d", start)
+			}
+
+			name := string(p[start+1 : i])
+			if names[name] {
+				return fmt.Errorf("duplicate variable {%s}", name)
+			}
+
+			names[name] = true
+			start = -1
+		case '/':
+			if start >= 0 {
+				return fmt.Errorf("unbalanced braces: unexpected / in template expression at position %d", i)
+			}
+		}
+	}
+
+	if start >= 0 {
+		return fmt.Errorf("unbalanced braces: { at position %d is not closed", start)
+	}
+
 	return nil
 }
@@ -92,6 +92,18 @@ func (p *PathItem) Operations(yield func(string, *Operation) bool) {
 	}
 }
 
+// Operation returns the operation for the given method, or nil if there is none.
+// The method is case-insensitive.
+func (p *PathItem) Operation(method string) *Operation {
+	for m, op := range p.Operations {
+		if strings.EqualFold(m, method) {
+			return op
+		}
+	}
+
+	return nil
+}
+
 // SetOperation sets the operation for the given method.
 // The method is case-insensitive.
 func (p *PathItem) SetOperation(method string, op *Operation) {
@@ -141,7 +153,7 @@ func (l *loader) collectPathItem(p *PathItem, ref ref) {
 }
 
 func (l *loader) resolvePathItemRef(ref *PathItemRef) error {
-	return resolveRef(ref, l.pathItems, l.resolvePathItem)
+	return resolveRef(l, ref, l.pathItems, l.resolvePathItem)
 }
 
 func (l *loader) resolvePathItem(p *PathItem) error {
@@ -3,16 +3,20 @@ package openapi
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var reTemplateExpressions = regexp.MustCompile(`\{([^}]+)\}`)
 
 // ParsedPath is a parsed URL path template.
 type ParsedPath struct {
-	// Format string with the variables replaced with %s.
+	// Format string with the variables replaced with %s and any literal % escaped as %%.
 	Format string
 	// VariableNames is the list of variable keys in order.
 	VariableNames []string
+
+	// the compiled segments of the path, see Match
+	segments []pathSegment
 }
 
 // Parse parses the path, returning a ParsedPath which includes a format specifier (see [fmt]) and a list of all variable names in order.
@@ -20,13 +24,15 @@ func (path Path) Parse() ParsedPath {
 	p := ParsedPath{}
 
 	// Find all variables in the url
-	p.Format = reTemplateExpressions.ReplaceAllStringFunc(string(path), func(s string) string {
+	p.Format = reTemplateExpressions.ReplaceAllStringFunc(strings.ReplaceAll(string(path), "%", "%%"), func(s string) string {
 		// store the variable name
 		p.VariableNames = append(p.VariableNames, s[1:len(s)-1])
 
 		return "%s"
 	})
 
+	p.segments = parsePathSegments(string(path))
+
 	return p
 }
 
@@ -38,3 +44,58 @@ func (p ParsedPath) String() string {
 
 	return fmt.Sprintf(p.Format, vars...)
 }
+
+// Build expands the path template with the given variable values.
+// Following the simple string expansion of RFC 6570, all characters of a value except unreserved ones are percent-encoded,
+// e.g. a `/` in a value becomes `%2F`. It returns an error if a variable has no value.
+func (p ParsedPath) Build(vars map[string]string) (string, error) {
+	values := make([]any, len(p.VariableNames))
+	for i, name := range p.VariableNames {
+		v, ok := vars[name]
+		if !ok {
+			return "", fmt.Errorf("missing value for {%s}", name)
+		}
+
+		values[i] = escapeTemplateValue(v)
+	}
+
+	return fmt.Sprintf(p.Format, values...), nil
+}
+
+// Match matches an escaped URL path against the path template and returns the unescaped variable values.
+// Variables do not match across slashes. A segment with several variables, e.g. `{owner}.{repo}`,
+// is split at the last occurrence of each separator.
+func (p ParsedPath) Match(escapedPath string) (map[string]string, bool) {
+	segments := p.segments
+	if segments == nil { // not created by Path.Parse
+		segments = parsePathSegments(p.String())
+	}
+
+	return matchSegments(segments, strings.Split(strings.TrimPrefix(escapedPath, "/"), "/"))
+}
+
+// escapeTemplateValue percent-encodes all characters except the unreserved ones of RFC 3986.
+func escapeTemplateValue(s string) string {
+	const upperhex = "0123456789ABCDEF"
+
+	b := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUnreserved(c) {
+			b.WriteByte(c)
+			continue
+		}
+
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15])
+	}
+
+	return b.String()
+}
+
+// isUnreserved reports whether the character is unreserved according to RFC 3986, i.e. ALPHA / DIGIT / "-" / "." / "_" / "~".
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
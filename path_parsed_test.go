@@ -1,6 +1,7 @@
 package openapi_test
 
 import (
+	"maps"
 	"testing"
 
 	"github.com/MarkRosemaker/openapi"
@@ -12,6 +13,7 @@ func TestParsedPath(t *testing.T) {
 		"foo/bar/baz",
 		"/users/{userid}/address",
 		"/2.0/repositories/{username}/{slug}",
+		"/discount/100%25/{code}",
 	} {
 		t.Run(string(path), func(t *testing.T) {
 			pp := path.Parse()
@@ -22,3 +24,56 @@ func TestParsedPath(t *testing.T) {
 		})
 	}
 }
+
+func TestParsedPath_BuildMatch(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		path openapi.Path
+		vars map[string]string
+		want string
+	}{
+		{"/users/{id}", map[string]string{"id": "42"}, "/users/42"},
+		{"/users/{id}", map[string]string{"id": "a/b c"}, "/users/a%2Fb%20c"},
+		{"/users/{id}/address", map[string]string{"id": "ünï?#"}, "/users/%C3%BCn%C3%AF%3F%23/address"},
+		{"/{owner}.{repo}", map[string]string{"owner": "golang", "repo": "go"}, "/golang.go"},
+		{"/{owner}.{repo}", map[string]string{"owner": "my.org", "repo": "go"}, "/my.org.go"},
+		{"/discount/100%25/{code}", map[string]string{"code": "~x_y-z."}, "/discount/100%25/~x_y-z."},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			pp := tc.path.Parse()
+
+			got, err := pp.Build(tc.vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("want: %s, got: %s", tc.want, got)
+			}
+
+			vars, ok := pp.Match(got)
+			if !ok {
+				t.Fatalf("%s does not match %s", got, tc.path)
+			}
+
+			if !maps.Equal(vars, tc.vars) {
+				t.Fatalf("want: %v, got: %v", tc.vars, vars)
+			}
+		})
+	}
+
+	pp := openapi.Path("/users/{id}").Parse()
+	if _, err := pp.Build(nil); err == nil || err.Error() != "missing value for {id}" {
+		t.Fatalf("want missing value error, got: %v", err)
+	}
+
+	if _, ok := pp.Match("/users/1/address"); ok {
+		t.Fatal("a variable must not match across slashes")
+	}
+	// a parsed path that was not created by Path.Parse
+	pp = openapi.ParsedPath{Format: "/users/%s", VariableNames: []string{"id"}}
+	if vars, ok := pp.Match("/users/42"); !ok || vars["id"] != "42" {
+		t.Fatalf("want id 42, got: %v, %t", vars, ok)
+	}
+}
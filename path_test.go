@@ -21,3 +21,34 @@ func TestPath_Validate_Error(t *testing.T) {
 		t.Fatalf("want: %s, got: %s", want, err)
 	}
 }
+
+func TestPath_Validate_Template(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		path openapi.Path
+		err  string
+	}{
+		{"/users/{id}", ""},
+		{"/repos/{owner}.{repo}/{ref}", ""},
+		{"/users?id=1", `path must not contain a query string or fragment, found '?'`},
+		{"/users#top", `path must not contain a query string or fragment, found '#'`},
+		{"/users/{id", `unbalanced braces: { at position 7 is not closed`},
+		{"/users/id}", `unbalanced braces: unexpected } at position 9`},
+		{"/users/{{id}}", `unbalanced braces: unexpected { at position 8`},
+		{"/users/{id/name}", `unbalanced braces: unexpected / in template expression at position 10`},
+		{"/users/{}", `empty template expression at position 7`},
+		{"/users/{id}/friends/{id}", `duplicate variable {id}`},
+	} {
+		t.Run(string(tc.path), func(t *testing.T) {
+			err := tc.path.Validate()
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -24,12 +24,20 @@ type Paths map[Path]*PathItem
 func (ps Paths) Validate() error {
 	// The id of an operation MUST be unique among all operations described in the API. The operationId value is case-sensitive.
 	opIDs := map[string]error{}
+	// Templated paths with the same hierarchy but different templated names MUST NOT exist as they are identical.
+	templates := map[string]Path{}
 
 	for path, pathItem := range ps.ByIndex() {
 		if err := path.Validate(); err != nil {
 			return &errpath.ErrKey{Key: string(path), Err: err}
 		}
 
+		if other, ok := templates[path.normalize()]; ok {
+			return &errpath.ErrKey{Key: string(path), Err: fmt.Errorf("%w: equivalent to %q", ErrAmbiguousPath, other)}
+		}
+
+		templates[path.normalize()] = path
+
 		// if path has path parameter, check if path parameter is defined
 		pp := path.Parse()
 		for _, vn := range pp.VariableNames {
@@ -97,6 +105,60 @@ func (ps Paths) Validate() error {
 	return nil
 }
 
+// overlaps reports pairs of paths that both match some concrete path, e.g. `/files/{path}` and `/files/latest`.
+// While the specification prefers concrete matches, routers differ in how they resolve such overlaps.
+// Each pair is reported once, at the path that comes later.
+func (ps Paths) overlaps() []error {
+	type template struct {
+		path     Path
+		segments []pathSegment
+	}
+
+	var (
+		errs []error
+		seen []template
+	)
+
+	for path := range ps.ByIndex() {
+		segments := parsePathSegments(string(path))
+		for _, other := range seen {
+			if path.normalize() != other.path.normalize() && segmentsOverlap(segments, other.segments) {
+				errs = append(errs, &errpath.ErrKey{Key: string(path), Err: fmt.Errorf("overlaps with %q", other.path)})
+			}
+		}
+
+		seen = append(seen, template{path, segments})
+	}
+
+	return errs
+}
+
+// segmentsOverlap reports whether some concrete path may match both templates.
+func segmentsOverlap(a, b []pathSegment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		switch {
+		case a[i].re == nil && b[i].re == nil:
+			if a[i].literal != b[i].literal {
+				return false
+			}
+		case a[i].re == nil:
+			if !b[i].re.MatchString(a[i].literal) {
+				return false
+			}
+		case b[i].re == nil:
+			if !a[i].re.MatchString(b[i].literal) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // ByIndex returns a sequence of key-value pairs ordered by index.
 func (ps Paths) ByIndex() iter.Seq2[Path, *PathItem] {
 	return ordmap.ByIndex(ps, getIndexPathItem)
@@ -54,6 +54,12 @@ func TestPaths_Validate_Error(t *testing.T) {
 		{openapi.Paths{"/user/{id}": {
 			Get: &openapi.Operation{},
 		}}, `["/user/{id}"].GET.parameters: {id} not defined`},
+		{func() openapi.Paths {
+			ps := openapi.Paths{}
+			ps.Set("/users/{id}", &openapi.PathItem{})
+			ps.Set("/users/{name}", &openapi.PathItem{})
+			return ps
+		}(), `["/users/{name}"]: ambiguous path: equivalent to "/users/{id}"`},
 	} {
 		t.Run(tc.err, func(t *testing.T) {
 			t.Parallel()
@@ -0,0 +1,325 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+// ErrUnsupportedPattern is returned when matching a pattern that uses ECMA-262 features the matcher can't evaluate.
+var ErrUnsupportedPattern = errors.New("pattern is not supported")
+
+// Pattern is a regular expression in the ECMA-262 dialect, as used by the `pattern` and `patternProperties` keywords.
+//
+// The pattern is evaluated by translating it to the RE2 syntax of the regexp package.
+// Patterns using lookaround assertions or backreferences can't be evaluated, yet they are kept,
+// so that documents using them can still be loaded, validated and written.
+// The zero value is the empty pattern, which matches any string.
+type Pattern struct {
+	source string
+	re     *regexp.Regexp
+	// why the pattern can't be evaluated, if it can't
+	unsupported error
+	// why the pattern is invalid, if it is
+	invalid error
+}
+
+// CompilePattern parses an ECMA-262 regular expression.
+// A pattern that is valid but can't be evaluated is returned without error, see Unsupported.
+func CompilePattern(source string) (*Pattern, error) {
+	p := compilePattern(source)
+	if p.invalid != nil {
+		return nil, p.invalid
+	}
+
+	return p, nil
+}
+
+// MustCompilePattern is like CompilePattern but panics if the pattern is invalid.
+func MustCompilePattern(source string) *Pattern {
+	p, err := CompilePattern(source)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func compilePattern(source string) *Pattern {
+	p := &Pattern{source: source}
+
+	expr, err := translateECMAPattern(source)
+	if err != nil {
+		p.unsupported = err
+		return p
+	}
+
+	if p.re, err = regexp.Compile(expr); err != nil {
+		// report the error for the original pattern
+		if reErr := (*syntax.Error)(nil); errors.As(err, &reErr) {
+			err = &syntax.Error{Code: reErr.Code, Expr: source}
+		}
+
+		p.invalid = err
+	}
+
+	return p
+}
+
+// String returns the source text of the pattern.
+func (p *Pattern) String() string { return p.source }
+
+// Unsupported returns an error describing why the pattern can't be evaluated, or nil if it can.
+func (p *Pattern) Unsupported() error { return p.unsupported }
+
+// Match reports whether the string contains any match of the pattern.
+// It returns ErrUnsupportedPattern if the pattern can't be evaluated.
+func (p *Pattern) Match(s string) (bool, error) {
+	switch {
+	case p.invalid != nil:
+		return false, p.invalid
+	case p.unsupported != nil:
+		return false, fmt.Errorf("%w: %w", ErrUnsupportedPattern, p.unsupported)
+	case p.re == nil: // the zero value
+		return true, nil
+	default:
+		return p.re.MatchString(s), nil
+	}
+}
+
+// Validate checks that the pattern is a valid regular expression.
+// Patterns that can't be evaluated are valid, see Unsupported.
+func (p *Pattern) Validate() error {
+	if p.invalid == nil {
+		return nil
+	}
+
+	return &errpath.ErrInvalid[string]{Value: p.source, Message: p.invalid.Error()}
+}
+
+var _ json.MarshalerTo = (*Pattern)(nil)
+
+// MarshalJSONTo marshals the source text of the pattern.
+func (p *Pattern) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return enc.WriteToken(jsontext.String(p.source))
+}
+
+var _ json.UnmarshalerFrom = (*Pattern)(nil)
+
+// UnmarshalJSONFrom unmarshals the pattern from its source text.
+// Invalid patterns are reported by Validate.
+func (p *Pattern) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	var source string
+	if err := json.UnmarshalDecode(dec, &source); err != nil {
+		return err
+	}
+
+	*p = *compilePattern(source)
+	return nil
+}
+
+// matchesAny reports whether the string may match any of the patterns,
+// where patterns that can't be evaluated are assumed to match.
+func matchesAny(patterns []*Pattern, s string) bool {
+	for _, p := range patterns {
+		if ok, err := p.Match(s); ok || err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// the largest repeat count RE2 accepts, where ECMA-262 has no limit
+const maxRE2Repeat = 1000
+
+// reECMARepeat matches a bounded quantifier, i.e. `{n}`, `{n,}` or `{n,m}`
+var reECMARepeat = regexp.MustCompile(`^\{(\d+)(,(\d*))?\}`)
+
+const (
+	// the line terminators of ECMA-262, which `.` does not match
+	ecmaLineTerminators = `\n\r\x{2028}\x{2029}`
+	// the white space and line terminators of ECMA-262, which `\s` matches
+	ecmaWhiteSpace = `\t\n\v\f\r \x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}`
+)
+
+// translateECMAPattern translates an ECMA-262 regular expression to the RE2 syntax.
+// It returns an error if the pattern uses features that RE2 does not have.
+// Syntax errors are left to the regexp package.
+func translateECMAPattern(source string) (string, error) {
+	var b strings.Builder
+	inClass := false
+
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+
+		switch {
+		case c == '\\' && i+1 < len(source):
+			i++
+			esc := source[i]
+
+			switch {
+			case esc >= '1' && esc <= '9':
+				return "", fmt.Errorf("backreference %q", source[i-1:i+1])
+			case esc == 'k' && i+1 < len(source) && source[i+1] == '<':
+				return "", errors.New("named backreference")
+			case esc == '0':
+				b.WriteString(`\x00`)
+			case esc == 'b' && inClass: // backspace
+				b.WriteString(`\x08`)
+			case esc == 's':
+				if inClass {
+					b.WriteString(ecmaWhiteSpace)
+				} else {
+					b.WriteString(`[` + ecmaWhiteSpace + `]`)
+				}
+			case esc == 'S':
+				if inClass {
+					return "", errors.New(`\S in character class`)
+				}
+
+				b.WriteString(`[^` + ecmaWhiteSpace + `]`)
+			case esc == 'c' && i+1 < len(source) && isASCIILetter(source[i+1]):
+				i++
+				fmt.Fprintf(&b, `\x{%x}`, source[i]%32)
+			case esc == 'u':
+				r, n, ok := parseECMAUnicodeEscape(source[i+1:])
+				if !ok {
+					b.WriteString(`\u`) // left to the regexp package
+					continue
+				}
+
+				i += n
+				fmt.Fprintf(&b, `\x{%x}`, r)
+			case esc == '/':
+				b.WriteByte('/')
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(esc)
+			}
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+
+			b.WriteByte(c)
+		case c == '[':
+			switch {
+			case strings.HasPrefix(source[i:], "[^]"): // any character
+				b.WriteString(`[\x{0}-\x{10ffff}]`)
+				i += 2
+			case strings.HasPrefix(source[i:], "[]"): // no character
+				b.WriteString(`[^\x{0}-\x{10ffff}]`)
+				i++
+			default:
+				inClass = true
+				b.WriteByte(c)
+			}
+		case c == '(' && strings.HasPrefix(source[i:], "(?="):
+			return "", errors.New("lookahead assertion")
+		case c == '(' && strings.HasPrefix(source[i:], "(?!"):
+			return "", errors.New("negative lookahead assertion")
+		case c == '(' && strings.HasPrefix(source[i:], "(?<="):
+			return "", errors.New("lookbehind assertion")
+		case c == '(' && strings.HasPrefix(source[i:], "(?<!"):
+			return "", errors.New("negative lookbehind assertion")
+		case c == '(' && strings.HasPrefix(source[i:], "(?<"):
+			b.WriteString("(?P<")
+			i += 2
+		case c == '.':
+			b.WriteString(`[^` + ecmaLineTerminators + `]`)
+		case c == '{':
+			if q, ok := repeatOverLimit(source[i:]); ok {
+				return "", fmt.Errorf("repeat count %q over %d", q, maxRE2Repeat)
+			}
+
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// repeatOverLimit reports whether the string starts with a valid quantifier whose count RE2 does not accept.
+// It returns the quantifier.
+// Quantifiers with a minimum above their maximum are invalid and left to the regexp package.
+func repeatOverLimit(s string) (string, bool) {
+	m := reECMARepeat.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+
+	lo, hi := repeatCount(m[1]), repeatCount(m[1])
+	switch {
+	case m[2] == "": // {n}
+	case m[3] == "": // {n,}
+	default: // {n,m}
+		if hi = repeatCount(m[3]); hi < lo {
+			return "", false
+		}
+	}
+
+	return m[0], hi > maxRE2Repeat
+}
+
+// repeatCount parses the digits of a repeat count, where counts too large for an int are the largest int.
+func repeatCount(digits string) int {
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return math.MaxInt
+	}
+
+	return n
+}
+
+func isASCIILetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+// parseECMAUnicodeEscape parses what follows `\u`, i.e. `XXXX` or `{X...}`, combining surrogate pairs.
+// It returns the code point and the number of bytes consumed.
+func parseECMAUnicodeEscape(s string) (rune, int, bool) {
+	if strings.HasPrefix(s, "{") {
+		end := strings.IndexByte(s, '}')
+		if end < 2 {
+			return 0, 0, false
+		}
+
+		r, err := strconv.ParseUint(s[1:end], 16, 32)
+		if err != nil || r > 0x10ffff {
+			return 0, 0, false
+		}
+
+		return rune(r), end + 1, true
+	}
+
+	if len(s) < 4 {
+		return 0, 0, false
+	}
+
+	r1, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	// a high surrogate followed by a low surrogate is a single code point
+	if utf16.IsSurrogate(rune(r1)) && len(s) >= 10 && s[4:6] == `\u` {
+		if r2, err := strconv.ParseUint(s[6:10], 16, 16); err == nil {
+			if r := utf16.DecodeRune(rune(r1), rune(r2)); r != unicode.ReplacementChar {
+				return r, 10, true
+			}
+		}
+	}
+
+	return rune(r1), 4, true
+}
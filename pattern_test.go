@@ -0,0 +1,153 @@
+package openapi_test
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestPattern_Match(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pattern string
+		match   []string
+		noMatch []string
+	}{
+		{`^[A-Z]`, []string{"Tom"}, []string{"tom", ""}},
+		{`^\d{3}$`, []string{"123"}, []string{"12", "1234"}},
+		{`^a.b$`, []string{"a-b", "aéb"}, []string{"a\nb", "a\u2028b"}},
+		{`^\s$`, []string{" ", "\u00a0", "\ufeff"}, []string{"a"}},
+		{`^\S+$`, []string{"ab"}, []string{"a b", "a\u3000b"}},
+		{`^[\s,]+$`, []string{" , "}, []string{"a"}},
+		{`^(?<year>\d{4})-(?<month>\d{2})$`, []string{"2024-01"}, []string{"2024-1"}},
+		{`^é\u{1F600}$`, []string{"é😀"}, []string{"e😀"}},
+		{`^\uD83D\uDE00$`, []string{"😀"}, []string{"\uFFFD"}},
+		{`^\cJ\0$`, []string{"\n\x00"}, []string{"J0"}},
+		{`^[\b]$`, []string{"\b"}, []string{"b"}},
+		{`^a\/b$`, []string{"a/b"}, []string{`a\/b`}},
+		{`^[^]$`, []string{"\n"}, []string{""}},
+		{`[]`, nil, []string{"", "a"}},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			p, err := openapi.CompilePattern(tc.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range tc.match {
+				if ok, err := p.Match(s); err != nil {
+					t.Fatal(err)
+				} else if !ok {
+					t.Errorf("want %q to match", s)
+				}
+			}
+
+			for _, s := range tc.noMatch {
+				if ok, err := p.Match(s); err != nil {
+					t.Fatal(err)
+				} else if ok {
+					t.Errorf("want %q not to match", s)
+				}
+			}
+		})
+	}
+}
+
+func TestPattern_Zero(t *testing.T) {
+	t.Parallel()
+
+	p := &openapi.Pattern{}
+	if ok, err := p.Match("a"); err != nil || !ok {
+		t.Fatalf("want match, got: %t, %v", ok, err)
+	}
+
+	s := &openapi.Schema{Pattern: p}
+	if err := s.ValidateJSON(jsontext.Value(`"a"`)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPattern_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pattern string
+		err     string
+	}{
+		{`^(?=.*\d)\w+$`, "lookahead assertion"},
+		{`^(?!foo)`, "negative lookahead assertion"},
+		{`(?<=\$)\d+`, "lookbehind assertion"},
+		{`(?<!-)\d+`, "negative lookbehind assertion"},
+		{`(a)\1`, `backreference "\\1"`},
+		{`(?<x>a)\k<x>`, "named backreference"},
+		{`[\S]`, `\S in character class`},
+		{`a{1001}`, `repeat count "{1001}" over 1000`},
+		{`^\d{2,5000}$`, `repeat count "{2,5000}" over 1000`},
+		{`x{99999999999999999999,}`, `repeat count "{99999999999999999999,}" over 1000`},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			p, err := openapi.CompilePattern(tc.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := p.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := p.Unsupported(); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+
+			if _, err := p.Match("a"); !errors.Is(err, openapi.ErrUnsupportedPattern) {
+				t.Fatalf("want: %v, got: %v", openapi.ErrUnsupportedPattern, err)
+			}
+		})
+	}
+}
+
+func TestPattern_JSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		data string
+		err  string
+	}{
+		{`{"type":"string","pattern":"^\\d+$"}`, ``},
+		{`{"type":"string","pattern":"^(?=a)"}`, ``},
+		{`{"type":"string","pattern":"^(a"}`, "pattern (\"^(a\") is invalid: error parsing regexp: missing closing ): `^(a`"},
+	} {
+		t.Run(tc.data, func(t *testing.T) {
+			s := &openapi.Schema{}
+			if err := json.Unmarshal([]byte(tc.data), s); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := s.Validate(); tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+
+			// the source text is kept as is
+			b, err := json.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != tc.data {
+				t.Fatalf("want: %s, got: %s", tc.data, b)
+			}
+		})
+	}
+
+	if _, err := openapi.CompilePattern("^(a"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -2,6 +2,7 @@ package openapi
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 )
 
@@ -11,10 +12,67 @@ func (r ref) String() string {
 	return strings.Join(r, "/")
 }
 
+// refKey returns the key under which the location identified by the URI is collected,
+// i.e. the URI without fragment followed by "#" and the unescaped fragment.
+func refKey(u *url.URL) string {
+	if u == nil {
+		return "#"
+	}
+
+	frag := u.Fragment
+	noFrag := *u
+	noFrag.Fragment, noFrag.RawFragment = "", ""
+
+	return noFrag.String() + "#" + frag
+}
+
+// resolveURI resolves the URI reference against the current base URI as described in RFC 3986.
+func (l *loader) resolveURI(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.base == nil {
+		return u, nil
+	}
+
+	return l.base.ResolveReference(u), nil
+}
+
+// resolveURIAgainst resolves the URI reference against the base URI given as collection key (see refKey).
+func resolveURIAgainst(key, s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == "#" { // no base URI
+		return u, nil
+	}
+
+	base, err := url.Parse(strings.TrimSuffix(key, "#"))
+	if err != nil {
+		return nil, err
+	}
+
+	return base.ResolveReference(u), nil
+}
+
+// escapeJSONPointer escapes a reference token of a JSON Pointer as described in RFC 6901.
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
 // collectResolveRefs expands references in a document that was just unmarshaled
 func (l *loader) collectResolveRefs(doc *Document) error {
+	doc.location = l.location
+	l.base = doc.baseURI(l.location)
+	l.document = l.base
+	l.dialect = doc.dialect()
+
 	// collect all the references
-	l.collectDocument(doc, []string{"#"})
+	l.collectDocument(doc, ref{refKey(l.base)})
 
 	// resolve all the references
 	if err := l.resolveDocument(doc); err != nil {
@@ -26,12 +84,14 @@ func (l *loader) collectResolveRefs(doc *Document) error {
 
 // resolveRef resolves a reference to a value or resolves the value itself
 func resolveRef[T any, O referencable[T]](
-	r *refOrValue[T, O], values map[string]*T, resolveValue func(*T) error,
+	l *loader, r *refOrValue[T, O], values map[string]*T, resolveValue func(*T) error,
 ) error {
 	if r.Ref != nil && r.Value == nil {
-		if val, ok := values[r.Ref.Identifier]; ok {
-			r.Value = val
-			return nil
+		if u, err := l.resolveURI(r.Ref.Identifier); err == nil {
+			if val, ok := values[refKey(u)]; ok {
+				r.Value = val
+				return nil
+			}
 		}
 
 		return fmt.Errorf("couldn't resolve %q", r.Ref.Identifier)
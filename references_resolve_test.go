@@ -172,6 +172,123 @@ func TestResolve(t *testing.T) {
 				}
 			}
 		}`,
+		`"components":{"schemas": {
+			"Pet": {
+				"$id": "https://example.com/schemas/pet",
+				"type": "object",
+				"properties": {
+					"tag": {"$ref": "tag"},
+					"name": {"type": "string"},
+					"nickname": {"$ref": "#/properties/name"}
+				}
+			},
+			"Tag": {"$id": "https://example.com/schemas/tag", "type": "string"},
+			"Pets": {
+				"type": "array",
+				"items": {"$ref": "https://example.com/schemas/pet"}
+			},
+			"Owner": {
+				"type": "object",
+				"properties": {"petName": {"$ref": "#/components/schemas/Pet/properties/name"}}
+			}
+		}}`,
+		`"components":{"schemas": {
+			"Labels": {
+				"type": "object",
+				"patternProperties": {"^x-": {"$ref": "#/components/schemas/Label"}},
+				"propertyNames": {"$ref": "#/components/schemas/Name"},
+				"dependentSchemas": {"x-color": {"$ref": "#/components/schemas/Colored"}},
+				"unevaluatedProperties": {"$ref": "#/components/schemas/Label"}
+			},
+			"Label": {"type": "string"},
+			"Name": {"type": "string", "pattern": "^[a-z-]+$"},
+			"Colored": {"type": "object", "required": ["x-hue"], "properties": {"x-hue": {"type": "integer"}}}
+		}}`,
+		`"components":{"schemas": {
+			"Location": {
+				"type": "array",
+				"maxItems": 2,
+				"prefixItems": [
+					{"$ref": "#/components/schemas/Coordinate"},
+					{"$ref": "#/components/schemas/Coordinate"}
+				]
+			},
+			"Roles": {
+				"type": "array",
+				"items": {"type": "string"},
+				"contains": {"$ref": "#/components/schemas/Coordinate"},
+				"unevaluatedItems": {"$ref": "#/components/schemas/Coordinate"}
+			},
+			"Coordinate": {"type": "number"}
+		}}`,
+		`"components":{"schemas": {
+			"Shared": {
+				"$defs": {
+					"Id": {"type": "string", "format": "uuid"},
+					"Name": {"$anchor": "Name", "type": "string"}
+				}
+			},
+			"Pet": {
+				"type": "object",
+				"properties": {
+					"id": {"$ref": "#/components/schemas/Shared/$defs/Id"},
+					"name": {"$ref": "#Name"}
+				}
+			}
+		}}`,
+		`"components":{"schemas": {
+			"Pet": {
+				"$id": "https://example.com/schemas/pet",
+				"type": "object",
+				"properties": {
+					"id": {"$ref": "#/$defs/Id"},
+					"owner": {"$ref": "#Owner"}
+				},
+				"$defs": {
+					"Id": {"type": "integer"},
+					"Owner": {"$anchor": "Owner", "type": "string"}
+				}
+			},
+			"Pets": {
+				"type": "array",
+				"items": {"$ref": "https://example.com/schemas/pet#Owner"}
+			}
+		}}`,
+		`"components":{"schemas": {
+			"Tree": {
+				"$id": "https://example.com/schemas/tree",
+				"$dynamicAnchor": "node",
+				"type": "object",
+				"properties": {
+					"data": true,
+					"children": {"type": "array", "items": {"$dynamicRef": "#node"}}
+				}
+			}
+		}}`,
+		`"components":{"schemas": {
+			"Strict": {
+				"type": "object",
+				"properties": {"legacy": {"$ref": "#/components/schemas/Nothing"}},
+				"additionalProperties": false
+			},
+			"Nothing": false
+		}}`,
+		`"components":{"schemas": {
+			"Payment": {
+				"type": "object",
+				"if": {"$ref": "#/components/schemas/CardMethod"},
+				"then": {"$ref": "#/components/schemas/Card"},
+				"else": {"$ref": "#/components/schemas/Sepa"}
+			},
+			"CardMethod": {"type": "object", "properties": {"method": {"const": "card"}}},
+			"Card": {"type": "object", "properties": {"card_number": {"type": "string"}}},
+			"Sepa": {"type": "object", "properties": {"iban": {"type": "string"}}}
+		}}`,
+		`"$self": "https://example.com/api/openapi.json",
+		"components":{"schemas": {
+			"Pet": {"allOf": [{"$ref": "https://example.com/api/openapi.json#/components/schemas/Dog"}]},
+			"Dog": {"type": "object"}
+		}}`,
 		`"components": {
 			"schemas": {"MySchema": {"type": "object"}},
 			"responses": {
@@ -339,6 +456,59 @@ func TestResolve_Error(t *testing.T) {
 		{`{"components":{"schemas": {"MySchema": {
 "additionalProperties": {"$ref": "#/components/schemas/Foo"}
 }}}}`, `components.schemas["MySchema"].additionalProperties: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"discriminator": {"propertyName": "kind", "mapping": {"foo": "Foo"}}
+}}}}`, `components.schemas["MySchema"].discriminator.mapping["foo"]: couldn't resolve "Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"discriminator": {"propertyName": "kind", "defaultMapping": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].discriminator.defaultMapping: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"if": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].if: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"if": {"type": "string"},
+"then": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].then: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"if": {"type": "string"},
+"else": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].else: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"prefixItems": [{"$ref": "#/components/schemas/Foo"}]
+}}}}`, `components.schemas["MySchema"].prefixItems[0]: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"contains": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].contains: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"unevaluatedItems": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].unevaluatedItems: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"patternProperties": {"^x-": {"$ref": "#/components/schemas/Foo"}}
+}}}}`, `components.schemas["MySchema"].patternProperties["^x-"]: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"propertyNames": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].propertyNames: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"dependentSchemas": {"foo": {"$ref": "#/components/schemas/Foo"}}
+}}}}`, `components.schemas["MySchema"].dependentSchemas["foo"]: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"unevaluatedProperties": {"$ref": "#/components/schemas/Foo"}
+}}}}`, `components.schemas["MySchema"].unevaluatedProperties: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"$defs": {"foo": {"$ref": "#/components/schemas/Foo"}}
+}}}}`, `components.schemas["MySchema"].$defs["foo"]: couldn't resolve "#/components/schemas/Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+"$dynamicRef": "#node"
+}}}}`, `components.schemas["MySchema"].$dynamicRef: couldn't resolve "#node"`},
+		{`{"components":{"schemas": {"MySchema": {
+"items": {"$ref": "#Foo"}
+}}}}`, `components.schemas["MySchema"].items: couldn't resolve "#Foo"`},
+		{`{"components":{"schemas": {"MySchema": {
+	"$id": "https://example.com/schemas/my",
+	"properties": {"foo": {"$ref": "#/components/schemas/Foo"}}
+},
+"Foo": {"type": "string"}
+}}}`, `components.schemas["MySchema"].properties["foo"]: couldn't resolve "#/components/schemas/Foo"`},
 	} {
 		data := []byte(tc.in)
 
@@ -366,3 +536,101 @@ func TestResolve_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadFromFiles(t *testing.T) {
+	t.Parallel()
+
+	// the files reference each other by their self-assigned URIs,
+	// which are unrelated to where the files are stored
+	dir := t.TempDir()
+	petsPath := filepath.Join(dir, "pets.json")
+	commonPath := filepath.Join(dir, "shared", "definitions", "common.yaml")
+
+	if err := os.WriteFile(petsPath, []byte(`{
+"openapi": "3.2.0",
+"$self": "https://example.com/apis/pets/openapi.json",
+"info": {"title": "Pets", "version": "1.0"},
+"paths": {"/pets": {"get": {
+	"parameters": [{"$ref": "../common/openapi.yaml#/components/parameters/limit"}],
+	"responses": {"200": {"$ref": "#/components/responses/Pets"}}
+}}},
+"components": {
+	"responses": {"Pets": {
+		"description": "A list of pets",
+		"content": {"application/json": {"schema": {
+			"type": "array",
+			"items": {"$ref": "https://example.com/apis/common/openapi.yaml#/components/schemas/Pet"}
+		}}}
+	}}
+}
+}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(commonPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(commonPath, []byte(`openapi: 3.2.0
+$self: https://example.com/apis/common/openapi.yaml
+info:
+  title: Common
+  version: "1.0"
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: ../pets/openapi.json#/components/schemas/Owner
+    Owner:
+      type: string
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := openapi.LoadFromFiles(petsPath, commonPath); err == nil {
+		t.Fatal("expected error")
+	} else if want := commonPath + `: components.schemas["Pet"].properties["owner"]: couldn't resolve "../pets/openapi.json#/components/schemas/Owner"`; err.Error() != want {
+		t.Fatalf("got: %v, want: %v", err, want)
+	}
+
+	// reference the schema in the document itself instead
+	common, err := os.ReadFile(commonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(commonPath, bytes.Replace(common,
+		[]byte("../pets/openapi.json#/components/schemas/Owner"),
+		[]byte("'#/components/schemas/Owner'"), 1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := openapi.LoadFromFiles(petsPath, commonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, doc := range docs {
+		if err := doc.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pets, commonDoc := docs[0], docs[1]
+	if got := pets.Paths["/pets"].Get.Parameters[0].Value; got != commonDoc.Components.Parameters["limit"].Value {
+		t.Fatalf("parameter not resolved to the other document: %v", got)
+	}
+
+	items := pets.Components.Responses["Pets"].Value.Content[openapi.MediaRangeJSON].Schema.Value.Items
+	if items.Value != commonDoc.Components.Schemas["Pet"] {
+		t.Fatalf("schema not resolved to the other document: %v", items.Value)
+	}
+}
@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/MarkRosemaker/errpath"
+import (
+	"strconv"
+
+	"github.com/MarkRosemaker/errpath"
+)
 
 type (
 	// SchemaRef is a reference to a Schema or an actual Schema.
@@ -36,6 +40,12 @@ func setIndexRef[T any, O referencable[T]](
 	return ref
 }
 
+func (l *loader) collectSchemaRefList(ss SchemaRefList, ref ref) {
+	for i, s := range ss {
+		l.collectSchemaRef(s, append(ref, strconv.Itoa(i)))
+	}
+}
+
 func (l *loader) resolveSchemaRefList(ss SchemaRefList) error {
 	for i, s := range ss {
 		if err := l.resolveSchemaRef(s); err != nil {
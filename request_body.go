@@ -57,5 +57,5 @@ func (l *loader) resolveRequestBody(r *RequestBody) error {
 }
 
 func (l *loader) resolveRequestBodyRef(r *RequestBodyRef) error {
-	return resolveRef(r, l.requestBodies, l.resolveRequestBody)
+	return resolveRef(l, r, l.requestBodies, l.resolveRequestBody)
 }
@@ -57,7 +57,7 @@ func (l *loader) collectResponse(r *Response, ref ref) {
 }
 
 func (l *loader) resolveResponseRef(r *ResponseRef) error {
-	return resolveRef(r, l.responses, l.resolveResponse)
+	return resolveRef(l, r, l.responses, l.resolveResponse)
 }
 
 func (l *loader) resolveResponse(r *Response) error {
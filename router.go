@@ -0,0 +1,414 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+var (
+	// ErrPathNotFound is returned if no path of the document matches the request.
+	ErrPathNotFound = errors.New("no matching path")
+	// ErrMethodNotAllowed is returned if a path matches the request, but it has no operation for the method of the request.
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	// ErrAmbiguousPath is returned if several paths match the request and none of them is more specific.
+	ErrAmbiguousPath = errors.New("ambiguous path")
+)
+
+// Router matches requests to the paths and operations of a document.
+//
+// Paths are matched below the base paths of their servers, i.e. the servers of the operation,
+// else those of the path item, else those of the document.
+// If several paths match, concrete segments are preferred over templated ones, from left to right,
+// e.g. `/pets/mine` is preferred over `/pets/{petId}`, which is preferred over `/{kind}/mine`.
+type Router struct {
+	// the base paths of all servers, longest first
+	bases []basePath
+	// the routes, most specific first
+	routes []*route
+}
+
+// Route is the result of matching a request.
+type Route struct {
+	// The path template that matched.
+	Path Path
+	// The path item of the path.
+	PathItem *PathItem
+	// The method of the request, in upper case.
+	Method string
+	// The operation for the method. It is nil if the method is not allowed.
+	Operation *Operation
+	// The unescaped values of the variables in the path template.
+	PathParams map[string]string
+}
+
+type route struct {
+	path     Path
+	item     *PathItem
+	segments []pathSegment
+	// the base paths of the path item, i.e. of its servers or else those of the document
+	bases map[string]bool
+	// the base paths of the operations with their own servers, by method
+	operationBases map[string]map[string]bool
+}
+
+// servedBelow reports whether any operation of the route is served below the base path.
+func (rt *route) servedBelow(base string) bool {
+	if rt.bases[base] {
+		return true
+	}
+
+	for _, bases := range rt.operationBases {
+		if bases[base] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// operation returns the operation for the method if it is served below the base path, or nil.
+func (rt *route) operation(method, base string) *Operation {
+	op := rt.item.Operation(method)
+	if op == nil {
+		return nil
+	}
+
+	bases, ok := rt.operationBases[strings.ToUpper(method)]
+	if !ok {
+		bases = rt.bases
+	}
+
+	if !bases[base] {
+		return nil
+	}
+
+	return op
+}
+
+// basePath is the path of a server URL.
+type basePath struct {
+	path     string
+	segments []pathSegment
+}
+
+// pathSegment is a segment of a path template, i.e. the part between two slashes.
+type pathSegment struct {
+	// the text of the segment, if it has no template expressions
+	literal string
+	// matches the segment, if it has template expressions
+	re *regexp.Regexp
+	// the variable names, in order of the submatches of re
+	names []string
+	// whether the segment is a single template expression without text
+	variableOnly bool
+}
+
+// specificity orders segments: literal segments first, then segments that mix text and variables, then lone variables.
+func (s pathSegment) specificity() int {
+	switch {
+	case s.re == nil:
+		return 0
+	case s.variableOnly:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// NewRouter compiles a router for the paths of the document.
+// It returns an error if two paths are equivalent, i.e. identical except for the names of their variables.
+func NewRouter(d *Document) (*Router, error) {
+	r := &Router{}
+
+	bases := map[string]bool{}
+	basePaths := func(servers Servers, fallback map[string]bool) map[string]bool {
+		if len(servers) == 0 {
+			return fallback
+		}
+
+		paths := map[string]bool{}
+		for _, s := range servers {
+			p := serverBasePath(s.URL)
+			paths[p] = true
+
+			if !bases[p] {
+				bases[p] = true
+				r.bases = append(r.bases, basePath{path: p, segments: parsePathSegments(p)})
+			}
+		}
+
+		return paths
+	}
+
+	// without servers, the server is `/`
+	docBases := basePaths(d.Servers, nil)
+	if docBases == nil {
+		docBases = basePaths(Servers{{URL: "/"}}, nil)
+	}
+
+	equivalent := map[string]Path{}
+	for path, item := range d.Paths.ByIndex() {
+		key := path.normalize()
+		if other, ok := equivalent[key]; ok {
+			return nil, &errpath.ErrField{Field: "paths", Err: &errpath.ErrKey{
+				Key: string(path),
+				Err: fmt.Errorf("%w: equivalent to %q", ErrAmbiguousPath, other),
+			}}
+		}
+
+		equivalent[key] = path
+		rt := &route{
+			path:     path,
+			item:     item,
+			segments: parsePathSegments(string(path)),
+			bases:    basePaths(item.Servers, docBases),
+		}
+
+		for method, op := range item.Operations {
+			if len(op.Servers) > 0 {
+				if rt.operationBases == nil {
+					rt.operationBases = map[string]map[string]bool{}
+				}
+
+				rt.operationBases[method] = basePaths(op.Servers, nil)
+			}
+		}
+
+		r.routes = append(r.routes, rt)
+	}
+
+	slices.SortStableFunc(r.bases, func(a, b basePath) int {
+		if c := len(b.segments) - len(a.segments); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.path, b.path)
+	})
+	slices.SortStableFunc(r.routes, func(a, b *route) int { return compareSpecificity(a.segments, b.segments) })
+
+	return r, nil
+}
+
+// FindRoute matches the method and path of the request.
+// If a path matches but has no operation for the method, the route is returned along with ErrMethodNotAllowed.
+// The values of the path variables are set on the request, see http.Request.SetPathValue,
+// so that runtime expressions can refer to them.
+func (r *Router) FindRoute(req *http.Request) (*Route, error) {
+	rt, err := r.Match(req.Method, req.URL.EscapedPath())
+	if rt != nil {
+		for name, value := range rt.PathParams {
+			req.SetPathValue(name, value)
+		}
+	}
+
+	return rt, err
+}
+
+// Match matches a method and an escaped URL path, see FindRoute.
+func (r *Router) Match(method, escapedPath string) (*Route, error) {
+	segments := strings.Split(strings.TrimPrefix(escapedPath, "/"), "/")
+
+	for _, base := range r.bases {
+		rest, ok := matchPrefix(base.segments, segments)
+		if !ok {
+			continue
+		}
+
+		found, params, err := r.match(base.path, rest)
+		if err != nil {
+			return nil, err
+		}
+
+		if found == nil {
+			continue
+		}
+
+		rt := &Route{Path: found.path, PathItem: found.item, Method: strings.ToUpper(method), PathParams: params}
+		if rt.Operation = found.operation(method, base.path); rt.Operation == nil {
+			return rt, ErrMethodNotAllowed
+		}
+
+		return rt, nil
+	}
+
+	return nil, ErrPathNotFound
+}
+
+// match returns the most specific route served below the base path that matches the segments, or nil if none does.
+func (r *Router) match(base string, segments []string) (*route, map[string]string, error) {
+	var found *route
+	var params map[string]string
+
+	for _, rt := range r.routes {
+		if found != nil && compareSpecificity(found.segments, rt.segments) != 0 {
+			break // all remaining routes are less specific
+		}
+
+		if !rt.servedBelow(base) {
+			continue
+		}
+
+		p, ok := matchSegments(rt.segments, segments)
+		if !ok {
+			continue
+		}
+
+		if found != nil {
+			return nil, nil, fmt.Errorf("%w: %q and %q", ErrAmbiguousPath, found.path, rt.path)
+		}
+
+		found, params = rt, p
+	}
+
+	return found, params, nil
+}
+
+// compareSpecificity orders templates with more specific segments first, from left to right.
+func compareSpecificity(a, b []pathSegment) int {
+	for i := range min(len(a), len(b)) {
+		if c := a[i].specificity() - b[i].specificity(); c != 0 {
+			return c
+		}
+	}
+
+	return len(b) - len(a)
+}
+
+// parsePathSegments splits a path template into segments, ignoring the leading slash.
+func parsePathSegments(template string) []pathSegment {
+	if template == "" {
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(template, "/"), "/")
+	segments := make([]pathSegment, len(parts))
+
+	for i, part := range parts {
+		locs := reTemplateExpressions.FindAllStringSubmatchIndex(part, -1)
+		if locs == nil {
+			segments[i] = pathSegment{literal: part}
+			continue
+		}
+
+		expr := &strings.Builder{}
+		expr.WriteByte('^')
+
+		prev := 0
+		for _, loc := range locs {
+			expr.WriteString(regexp.QuoteMeta(part[prev:loc[0]]))
+			expr.WriteString("(.+)")
+			segments[i].names = append(segments[i].names, part[loc[2]:loc[3]])
+			prev = loc[1]
+		}
+
+		expr.WriteString(regexp.QuoteMeta(part[prev:]))
+		expr.WriteByte('$')
+
+		segments[i].re = regexp.MustCompile(expr.String())
+		segments[i].variableOnly = len(locs) == 1 && locs[0][0] == 0 && locs[0][1] == len(part)
+	}
+
+	return segments
+}
+
+// matchSegments matches escaped path segments against template segments and returns the unescaped variable values.
+func matchSegments(template []pathSegment, segments []string) (map[string]string, bool) {
+	if len(template) != len(segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, s := range template {
+		if s.re == nil {
+			// the literal is compared unescaped, since either may escape more characters than necessary
+			if segments[i] != s.literal && unescapePathSegment(segments[i]) != unescapePathSegment(s.literal) {
+				return nil, false
+			}
+
+			continue
+		}
+
+		m := s.re.FindStringSubmatch(segments[i])
+		if m == nil {
+			return nil, false
+		}
+
+		for j, name := range s.names {
+			v, err := url.PathUnescape(m[j+1])
+			if err != nil {
+				return nil, false
+			}
+
+			params[name] = v
+		}
+	}
+
+	return params, true
+}
+
+// unescapePathSegment unescapes a path segment, or returns it as is if it is not escaped correctly.
+func unescapePathSegment(s string) string {
+	if v, err := url.PathUnescape(s); err == nil {
+		return v
+	}
+
+	return s
+}
+
+// matchPrefix matches the leading segments of the path against a base path and returns the remaining segments.
+// If nothing remains, the remaining path is the root path.
+func matchPrefix(base []pathSegment, segments []string) ([]string, bool) {
+	if len(base) > len(segments) {
+		return nil, false
+	}
+
+	if _, ok := matchSegments(base, segments[:len(base)]); !ok {
+		return nil, false
+	}
+
+	if len(base) == len(segments) {
+		return []string{""}, true
+	}
+
+	return segments[len(base):], true
+}
+
+// serverBasePath returns the path of a server URL without a trailing slash.
+// The URL may contain server variables and may be relative.
+func serverBasePath(u string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+
+	// skip the scheme and authority
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+len("://"):]
+		if j := strings.IndexByte(u, '/'); j >= 0 {
+			u = u[j:]
+		} else {
+			u = ""
+		}
+	} else if strings.HasPrefix(u, "//") {
+		u = strings.TrimPrefix(u, "//")
+		if j := strings.IndexByte(u, '/'); j >= 0 {
+			u = u[j:]
+		} else {
+			u = ""
+		}
+	}
+
+	return strings.TrimSuffix(u, "/")
+}
+
+// normalize returns the path with the names of its variables removed,
+// so that equivalent templates are equal.
+func (p Path) normalize() string {
+	return reTemplateExpressions.ReplaceAllString(string(p), "{}")
+}
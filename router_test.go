@@ -0,0 +1,199 @@
+package openapi_test
+
+import (
+	"errors"
+	"maps"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+const routerDoc = `{
+"openapi": "3.2.0",
+"info": {"title": "Pets", "version": "1.0"},
+"servers": [{"url": "https://{region}.example.com/{version}/api", "variables": {"region": {"default": "eu"}, "version": {"default": "v1"}}}, {"url": "/"}],
+"paths": {
+	"/": {"get": {"operationId": "root"}},
+	"/pets": {"get": {"operationId": "listPets"}, "post": {"operationId": "createPet"}},
+	"/pets/{petId}": {"get": {"operationId": "getPet"}, "parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}}]},
+	"/pets/mine": {"get": {"operationId": "listMyPets"}},
+	"/{kind}/mine": {"get": {"operationId": "listMine"}, "parameters": [{"name": "kind", "in": "path", "required": true, "schema": {"type": "string"}}]},
+	"/repos/{owner}.{repo}": {"get": {"operationId": "getRepo"}, "parameters": [
+		{"name": "owner", "in": "path", "required": true, "schema": {"type": "string"}},
+		{"name": "repo", "in": "path", "required": true, "schema": {"type": "string"}}
+	]},
+	"/repos/{name}": {"get": {"operationId": "getRepoByName"}, "parameters": [{"name": "name", "in": "path", "required": true, "schema": {"type": "string"}}]},
+	"/files/{a}.{b}": {"get": {"operationId": "getFileDot"}, "parameters": [
+		{"name": "a", "in": "path", "required": true, "schema": {"type": "string"}},
+		{"name": "b", "in": "path", "required": true, "schema": {"type": "string"}}
+	]},
+	"/files/{a}-{b}": {"get": {"operationId": "getFileDash"}, "parameters": [
+		{"name": "a", "in": "path", "required": true, "schema": {"type": "string"}},
+		{"name": "b", "in": "path", "required": true, "schema": {"type": "string"}}
+	]}
+}
+}`
+
+func TestRouter(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(routerDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := openapi.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		method, target string
+		path           openapi.Path
+		operationID    string
+		params         map[string]string
+		err            error
+	}{
+		{"GET", "/", "/", "root", map[string]string{}, nil},
+		{"GET", "/v1/api", "/", "root", map[string]string{}, nil},
+		{"GET", "/pets", "/pets", "listPets", map[string]string{}, nil},
+		{"post", "/v2/api/pets", "/pets", "createPet", map[string]string{}, nil},
+		{"GET", "/pets/mine", "/pets/mine", "listMyPets", map[string]string{}, nil},
+		{"GET", "/pets/42", "/pets/{petId}", "getPet", map[string]string{"petId": "42"}, nil},
+		{"GET", "/pets/a%2Fb", "/pets/{petId}", "getPet", map[string]string{"petId": "a/b"}, nil},
+		{"GET", "/toys/mine", "/{kind}/mine", "listMine", map[string]string{"kind": "toys"}, nil},
+		{"GET", "/repos/golang.go", "/repos/{owner}.{repo}", "getRepo", map[string]string{"owner": "golang", "repo": "go"}, nil},
+		{"GET", "/repos/golang", "/repos/{name}", "getRepoByName", map[string]string{"name": "golang"}, nil},
+		{"DELETE", "/pets/42", "/pets/{petId}", "", map[string]string{"petId": "42"}, openapi.ErrMethodNotAllowed},
+		{"GET", "/pets/42/toys", "", "", nil, openapi.ErrPathNotFound},
+		{"GET", "/files/a.b-c", "", "", nil, openapi.ErrAmbiguousPath},
+	} {
+		t.Run(tc.method+" "+tc.target, func(t *testing.T) {
+			route, err := r.FindRoute(httptest.NewRequest(tc.method, tc.target, nil))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("want: %v, got: %v", tc.err, err)
+			}
+
+			if tc.path == "" {
+				if route != nil {
+					t.Fatalf("want no route, got: %+v", route)
+				}
+
+				return
+			}
+
+			if route.Path != tc.path {
+				t.Fatalf("want path %q, got %q", tc.path, route.Path)
+			}
+
+			if tc.operationID == "" {
+				if route.Operation != nil {
+					t.Fatalf("want no operation, got %q", route.Operation.OperationID)
+				}
+			} else if route.Operation == nil || route.Operation.OperationID != tc.operationID {
+				t.Fatalf("want operation %q, got %+v", tc.operationID, route.Operation)
+			}
+
+			if !maps.Equal(route.PathParams, tc.params) {
+				t.Fatalf("want params %v, got %v", tc.params, route.PathParams)
+			}
+		})
+	}
+}
+
+func TestRouter_Servers(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"info": {"title": "Pets", "version": "1.0"},
+"servers": [{"url": "https://example.com/v1"}],
+"paths": {
+	"/pets": {"get": {"operationId": "listPets"}, "delete": {"operationId": "deletePets", "servers": [{"url": "/admin"}]}},
+	"/stats": {"servers": [{"url": "https://stats.example.com/"}], "get": {"operationId": "getStats"}}
+}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := openapi.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		method, target string
+		operationID    string
+		err            error
+	}{
+		{"GET", "/v1/pets", "listPets", nil},
+		{"DELETE", "/admin/pets", "deletePets", nil},
+		{"DELETE", "/v1/pets", "", openapi.ErrMethodNotAllowed},
+		{"GET", "/admin/pets", "", openapi.ErrMethodNotAllowed},
+		{"GET", "/stats", "getStats", nil},
+		{"GET", "/v1/stats", "", openapi.ErrPathNotFound},
+		{"GET", "/pets", "", openapi.ErrPathNotFound},
+	} {
+		t.Run(tc.method+" "+tc.target, func(t *testing.T) {
+			route, err := r.FindRoute(httptest.NewRequest(tc.method, tc.target, nil))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("want: %v, got: %v", tc.err, err)
+			}
+
+			if tc.operationID == "" {
+				if route != nil && route.Operation != nil {
+					t.Fatalf("want no operation, got %q", route.Operation.OperationID)
+				}
+			} else if route == nil || route.Operation == nil || route.Operation.OperationID != tc.operationID {
+				t.Fatalf("want operation %q, got %+v", tc.operationID, route)
+			}
+		})
+	}
+}
+
+func TestRouter_FindRoute_PathValues(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(routerDoc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := openapi.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest("GET", "/v1/api/repos/golang.go", nil)
+	if _, err := r.FindRoute(req); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := openapi.RuntimeExpression("{$request.path.owner}/{$request.path.repo}").Evaluate(req, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "golang/go"; got != want {
+		t.Fatalf("want: %q, got: %q", want, got)
+	}
+}
+
+func TestNewRouter_Error(t *testing.T) {
+	t.Parallel()
+
+	doc := &openapi.Document{}
+	doc.Paths.Set("/users/{id}", &openapi.PathItem{})
+	doc.Paths.Set("/users/{name}", &openapi.PathItem{})
+
+	_, err := openapi.NewRouter(doc)
+	if !errors.Is(err, openapi.ErrAmbiguousPath) {
+		t.Fatalf("want: %v, got: %v", openapi.ErrAmbiguousPath, err)
+	}
+
+	if want := `paths["/users/{name}"]: ambiguous path: equivalent to "/users/{id}"`; err.Error() != want {
+		t.Fatalf("want: %s, got: %s", want, err)
+	}
+}
@@ -1,6 +1,22 @@
 package openapi
 
-import "github.com/MarkRosemaker/errpath"
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+// ErrExpressionValueNotFound is returned if the value that a runtime expression refers to is not part of the request or response.
+var ErrExpressionValueNotFound = errors.New("value not found")
 
 // Runtime expressions allow defining values based on information that will only be available within the HTTP message in an actual API call.
 // This mechanism is used by Link Objects and Callback Objects.
@@ -49,10 +65,407 @@ import "github.com/MarkRosemaker/errpath"
 // [RFC7230]: https://tools.ietf.org/html/rfc7230#section-3.2.6
 type RuntimeExpression string
 
+// Validate validates the runtime expression, see Parse.
 func (expr RuntimeExpression) Validate() error {
 	if expr == "" {
 		return &errpath.ErrRequired{}
 	}
 
-	return nil
+	_, err := expr.Parse()
+	return err
+}
+
+// Evaluate parses and evaluates the runtime expression, see ParsedRuntimeExpression.Evaluate.
+func (expr RuntimeExpression) Evaluate(req *http.Request, res *http.Response) (any, error) {
+	p, err := expr.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Evaluate(req, res)
+}
+
+// ExpressionSource is the part of the HTTP message that an expression refers to.
+type ExpressionSource string
+
+const (
+	// The URL of the request, e.g. `$url`.
+	ExpressionSourceURL ExpressionSource = "$url"
+	// The method of the request, e.g. `$method`.
+	ExpressionSourceMethod ExpressionSource = "$method"
+	// The status code of the response, e.g. `$statusCode`.
+	ExpressionSourceStatusCode ExpressionSource = "$statusCode"
+	// A value of the request, e.g. `$request.path.id`.
+	ExpressionSourceRequest ExpressionSource = "$request"
+	// A value of the response, e.g. `$response.header.Location`.
+	ExpressionSourceResponse ExpressionSource = "$response"
+)
+
+// ExpressionLocation is where a value of a request or response is located.
+type ExpressionLocation string
+
+const (
+	// A header, e.g. `$request.header.accept`.
+	ExpressionLocationHeader ExpressionLocation = "header"
+	// A query parameter, e.g. `$request.query.limit`.
+	ExpressionLocationQuery ExpressionLocation = "query"
+	// A path parameter, e.g. `$request.path.id`.
+	ExpressionLocationPath ExpressionLocation = "path"
+	// The body or a part of it, e.g. `$response.body#/status`.
+	ExpressionLocationBody ExpressionLocation = "body"
+)
+
+// Expression is a single runtime expression, e.g. `$request.body#/user/uuid`.
+type Expression struct {
+	// The part of the HTTP message that the expression refers to.
+	Source ExpressionSource
+	// Where the value is located, if the source is a request or response.
+	Location ExpressionLocation
+	// The name of the header, query parameter or path parameter.
+	Name string
+	// The JSON pointer into the body. It is empty for the entire body.
+	Pointer jsontext.Pointer
+	// Whether the body reference has a fragment, i.e. `#`, even if the pointer is empty.
+	HasPointer bool
+}
+
+// String returns the runtime expression.
+func (e *Expression) String() string {
+	switch e.Location {
+	case "":
+		return string(e.Source)
+	case ExpressionLocationBody:
+		if e.HasPointer {
+			return string(e.Source) + ".body#" + string(e.Pointer)
+		}
+
+		return string(e.Source) + ".body"
+	default:
+		return string(e.Source) + "." + string(e.Location) + "." + e.Name
+	}
+}
+
+// ExpressionPart is a part of a parsed runtime expression: either literal text or an expression.
+type ExpressionPart struct {
+	// The literal text, if the part is not an expression.
+	Literal string
+	// The expression, if the part is one.
+	Expression *Expression
+}
+
+// ParsedRuntimeExpression is a parsed runtime expression.
+// It is either a single expression, a string with expressions embedded in curly braces, or a constant.
+type ParsedRuntimeExpression struct {
+	// The parts of the runtime expression, in order.
+	Parts []ExpressionPart
+}
+
+// ExpressionSyntaxError is returned if a runtime expression is malformed.
+type ExpressionSyntaxError struct {
+	// The byte offset in the runtime expression where the error occurred.
+	Offset int
+	// What is wrong.
+	Message string
+}
+
+func (e *ExpressionSyntaxError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Message)
+}
+
+// Parse parses the runtime expression.
+// A value that starts with `$` is a single expression.
+// Otherwise, expressions can be embedded by surrounding them with curly braces, e.g. `{$request.body#/callbackUrl}?event=x`,
+// and any other text is literal, i.e. a value without embedded expressions is a constant.
+func (expr RuntimeExpression) Parse() (ParsedRuntimeExpression, error) {
+	s := string(expr)
+	if strings.HasPrefix(s, "$") {
+		e, err := parseExpression(s, 0)
+		if err != nil {
+			return ParsedRuntimeExpression{}, err
+		}
+
+		return ParsedRuntimeExpression{Parts: []ExpressionPart{{Expression: e}}}, nil
+	}
+
+	p := ParsedRuntimeExpression{}
+	for offset := 0; offset < len(s); {
+		start := strings.Index(s[offset:], "{$")
+		if start < 0 {
+			p.Parts = append(p.Parts, ExpressionPart{Literal: s[offset:]})
+			break
+		}
+
+		start += offset
+		if start > offset {
+			p.Parts = append(p.Parts, ExpressionPart{Literal: s[offset:start]})
+		}
+
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return ParsedRuntimeExpression{}, &ExpressionSyntaxError{Offset: start, Message: "missing closing brace"}
+		}
+
+		end += start
+		e, err := parseExpression(s[start+1:end], start+1)
+		if err != nil {
+			return ParsedRuntimeExpression{}, err
+		}
+
+		p.Parts = append(p.Parts, ExpressionPart{Expression: e})
+		offset = end + 1
+	}
+
+	return p, nil
+}
+
+// parseExpression parses a single expression that starts at the given offset in the runtime expression.
+func parseExpression(s string, offset int) (*Expression, error) {
+	switch src := ExpressionSource(s); src {
+	case ExpressionSourceURL, ExpressionSourceMethod, ExpressionSourceStatusCode:
+		return &Expression{Source: src}, nil
+	}
+
+	src, rest, ok := strings.Cut(s, ".")
+	switch ExpressionSource(src) {
+	case ExpressionSourceRequest, ExpressionSourceResponse:
+	default:
+		return nil, &ExpressionSyntaxError{Offset: offset, Message: fmt.Sprintf("unknown expression %q", s)}
+	}
+
+	e := &Expression{Source: ExpressionSource(src)}
+	offset += len(src) + 1
+	if !ok {
+		return nil, &ExpressionSyntaxError{Offset: offset - 1, Message: fmt.Sprintf("expected header, query, path or body after %s", src)}
+	}
+
+	if body, ok := strings.CutPrefix(rest, string(ExpressionLocationBody)); ok && (body == "" || body[0] == '#') {
+		e.Location = ExpressionLocationBody
+		if body == "" {
+			return e, nil
+		}
+
+		ptr := body[1:]
+		if ptr != "" && ptr[0] != '/' {
+			return nil, &ExpressionSyntaxError{Offset: offset + len("body#"), Message: "JSON pointer must start with a slash"}
+		}
+
+		for i := 0; i < len(ptr); i++ {
+			if ptr[i] == '~' && (i+1 == len(ptr) || (ptr[i+1] != '0' && ptr[i+1] != '1')) {
+				return nil, &ExpressionSyntaxError{Offset: offset + len("body#") + i, Message: "invalid escape in JSON pointer"}
+			}
+		}
+
+		e.Pointer, e.HasPointer = jsontext.Pointer(ptr), true
+		return e, nil
+	}
+
+	loc, name, ok := strings.Cut(rest, ".")
+	switch e.Location = ExpressionLocation(loc); e.Location {
+	case ExpressionLocationHeader, ExpressionLocationQuery, ExpressionLocationPath:
+	default:
+		return nil, &ExpressionSyntaxError{Offset: offset, Message: fmt.Sprintf("unknown source %q, expected header, query, path or body", loc)}
+	}
+
+	offset += len(loc) + 1
+	if !ok || name == "" {
+		return nil, &ExpressionSyntaxError{Offset: offset, Message: fmt.Sprintf("missing %s name", loc)}
+	}
+
+	if e.Location == ExpressionLocationHeader {
+		if i := strings.IndexFunc(name, func(r rune) bool { return r > 127 || !isTokenChar(byte(r)) }); i >= 0 {
+			return nil, &ExpressionSyntaxError{Offset: offset + i, Message: fmt.Sprintf("invalid character %q in header name", name[i])}
+		}
+	}
+
+	e.Name = name
+	return e, nil
+}
+
+// isTokenChar reports whether the character is a tchar of RFC 7230.
+func isTokenChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
+
+// Evaluate evaluates the runtime expression for a request and its response, which may be nil if no expression refers to it.
+// A single expression, embedded or not, preserves the type of its value, see Expression.Evaluate.
+// Otherwise, the values of the expressions are embedded into the string, where objects and arrays are encoded as JSON.
+func (p ParsedRuntimeExpression) Evaluate(req *http.Request, res *http.Response) (any, error) {
+	if len(p.Parts) == 1 && p.Parts[0].Expression != nil {
+		return p.Parts[0].Expression.Evaluate(req, res)
+	}
+
+	b := &strings.Builder{}
+	for _, part := range p.Parts {
+		if part.Expression == nil {
+			b.WriteString(part.Literal)
+			continue
+		}
+
+		v, err := part.Expression.Evaluate(req, res)
+		if err != nil {
+			return nil, err
+		}
+
+		switch v := v.(type) {
+		case string:
+			b.WriteString(v)
+		case int:
+			b.WriteString(strconv.Itoa(v))
+		default:
+			data, err := json.Marshal(v, json.Deterministic(true))
+			if err != nil {
+				return nil, err
+			}
+
+			b.Write(data)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Evaluate evaluates the expression for a request and its response.
+//
+// The status code is an int, a JSON body or the part of it that the pointer refers to is decoded
+// like into an `any`, except that numbers are of type Number, and any other body and all other values are strings.
+// A body is decoded as JSON unless its content type says otherwise.
+// Path parameters are taken from the request, see http.Request.PathValue, as set by Router.FindRoute.
+// The bodies are read, but remain readable.
+func (e *Expression) Evaluate(req *http.Request, res *http.Response) (any, error) {
+	if e.Source == ExpressionSourceStatusCode || e.Source == ExpressionSourceResponse {
+		if res == nil {
+			return nil, fmt.Errorf("%s: no response", e)
+		}
+	} else if req == nil {
+		return nil, fmt.Errorf("%s: no request", e)
+	}
+
+	switch e.Source {
+	case ExpressionSourceURL:
+		return requestURL(req), nil
+	case ExpressionSourceMethod:
+		return req.Method, nil
+	case ExpressionSourceStatusCode:
+		return res.StatusCode, nil
+	}
+
+	header, body := req.Header, &req.Body
+	if e.Source == ExpressionSourceResponse {
+		header, body = res.Header, &res.Body
+	}
+
+	switch e.Location {
+	case ExpressionLocationHeader:
+		if vals := header.Values(e.Name); len(vals) > 0 {
+			return vals[0], nil
+		}
+	case ExpressionLocationQuery:
+		if e.Source == ExpressionSourceRequest {
+			if vals, ok := req.URL.Query()[e.Name]; ok && len(vals) > 0 {
+				return vals[0], nil
+			}
+		}
+	case ExpressionLocationPath:
+		if e.Source == ExpressionSourceRequest {
+			if v := req.PathValue(e.Name); v != "" {
+				return v, nil
+			}
+		}
+	case ExpressionLocationBody:
+		return e.evaluateBody(header, body)
+	}
+
+	return nil, fmt.Errorf("%s: %w", e, ErrExpressionValueNotFound)
+}
+
+func (e *Expression) evaluateBody(header http.Header, body *io.ReadCloser) (any, error) {
+	data, err := readBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e, err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s: %w", e, ErrExpressionValueNotFound)
+	}
+
+	if !isJSONBody(header) {
+		if e.Pointer != "" {
+			return nil, fmt.Errorf("%s: body is not JSON", e)
+		}
+
+		return string(data), nil
+	}
+
+	v, err := unmarshalInstance(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e, err)
+	}
+
+	for tok := range e.Pointer.Tokens() {
+		switch val := v.(type) {
+		case map[string]any:
+			var ok bool
+			if v, ok = val[tok]; !ok {
+				return nil, fmt.Errorf("%s: %w", e, ErrExpressionValueNotFound)
+			}
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(val) || strconv.Itoa(i) != tok {
+				return nil, fmt.Errorf("%s: %w", e, ErrExpressionValueNotFound)
+			}
+
+			v = val[i]
+		default:
+			return nil, fmt.Errorf("%s: %w", e, ErrExpressionValueNotFound)
+		}
+	}
+
+	return v, nil
+}
+
+// readBody reads the body and replaces it with a reader of the same data.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := (*body).Close(); err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// isJSONBody reports whether the content type is JSON or not set.
+func isJSONBody(header http.Header) bool {
+	ct := header.Get("Content-Type")
+	if ct == "" {
+		return true
+	}
+
+	mt, _, err := mime.ParseMediaType(ct)
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+// requestURL returns the absolute URL of the request, including the scheme and host of incoming requests.
+func requestURL(req *http.Request) string {
+	u := *req.URL
+	if u.Host == "" {
+		u.Host = req.Host
+	}
+
+	if u.Scheme == "" {
+		u.Scheme = "http"
+		if req.TLS != nil {
+			u.Scheme = "https"
+		}
+	}
+
+	return u.String()
 }
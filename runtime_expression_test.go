@@ -0,0 +1,174 @@
+package openapi_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestRuntimeExpression_Parse(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		expr openapi.RuntimeExpression
+		want []openapi.ExpressionPart
+	}{
+		{"$url", []openapi.ExpressionPart{{Expression: &openapi.Expression{Source: openapi.ExpressionSourceURL}}}},
+		{"$statusCode", []openapi.ExpressionPart{{Expression: &openapi.Expression{Source: openapi.ExpressionSourceStatusCode}}}},
+		{"$request.header.X-Rate-Limit", []openapi.ExpressionPart{{Expression: &openapi.Expression{
+			Source: openapi.ExpressionSourceRequest, Location: openapi.ExpressionLocationHeader, Name: "X-Rate-Limit",
+		}}}},
+		{"$request.path.id", []openapi.ExpressionPart{{Expression: &openapi.Expression{
+			Source: openapi.ExpressionSourceRequest, Location: openapi.ExpressionLocationPath, Name: "id",
+		}}}},
+		{"$response.body", []openapi.ExpressionPart{{Expression: &openapi.Expression{
+			Source: openapi.ExpressionSourceResponse, Location: openapi.ExpressionLocationBody,
+		}}}},
+		{"$response.body#/a~1b/0", []openapi.ExpressionPart{{Expression: &openapi.Expression{
+			Source: openapi.ExpressionSourceResponse, Location: openapi.ExpressionLocationBody, Pointer: "/a~1b/0", HasPointer: true,
+		}}}},
+		{"{$request.body#/callbackUrl}?event=x", []openapi.ExpressionPart{
+			{Expression: &openapi.Expression{
+				Source: openapi.ExpressionSourceRequest, Location: openapi.ExpressionLocationBody, Pointer: "/callbackUrl", HasPointer: true,
+			}},
+			{Literal: "?event=x"},
+		}},
+		{"http://example.com?id={$request.query.id}&method={$method}", []openapi.ExpressionPart{
+			{Literal: "http://example.com?id="},
+			{Expression: &openapi.Expression{Source: openapi.ExpressionSourceRequest, Location: openapi.ExpressionLocationQuery, Name: "id"}},
+			{Literal: "&method="},
+			{Expression: &openapi.Expression{Source: openapi.ExpressionSourceMethod}},
+		}},
+		{"constant {not an expression}", []openapi.ExpressionPart{{Literal: "constant {not an expression}"}}},
+	} {
+		t.Run(string(tc.expr), func(t *testing.T) {
+			p, err := tc.expr.Parse()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(p.Parts, tc.want) {
+				t.Fatalf("want: %+v, got: %+v", tc.want, p.Parts)
+			}
+
+			for _, part := range p.Parts {
+				if part.Expression != nil && !strings.Contains(string(tc.expr), part.Expression.String()) {
+					t.Fatalf("%q does not contain %q", tc.expr, part.Expression)
+				}
+			}
+		})
+	}
+}
+
+func TestRuntimeExpression_Parse_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		expr openapi.RuntimeExpression
+		err  string
+	}{
+		{"$foo", `offset 0: unknown expression "$foo"`},
+		{"$request", `offset 8: expected header, query, path or body after $request`},
+		{"$request.cookie.id", `offset 9: unknown source "cookie", expected header, query, path or body`},
+		{"$request.query.", `offset 15: missing query name`},
+		{"$request.header.X Y", `offset 17: invalid character ' ' in header name`},
+		{"$response.body#foo", `offset 15: JSON pointer must start with a slash`},
+		{"$response.body#/a~2", `offset 17: invalid escape in JSON pointer`},
+		{"http://example.com/{$request.body#/url", `offset 19: missing closing brace`},
+		{"http://example.com/{$requests}", `offset 20: unknown expression "$requests"`},
+	} {
+		t.Run(string(tc.expr), func(t *testing.T) {
+			_, err := tc.expr.Parse()
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+
+			synErr := &openapi.ExpressionSyntaxError{}
+			if !errors.As(err, &synErr) {
+				t.Fatalf("want an %T, got: %T", synErr, err)
+			}
+
+			if err := tc.expr.Validate(); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRuntimeExpression_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/users/42?limit=10&limit=20",
+		strings.NewReader(`{"callbackUrl": "https://client.example.com/hook", "user": {"id": 7, "tags": ["a", "b"]}}`))
+	req.Header.Set("X-Request-Id", "abc")
+	req.SetPathValue("id", "42")
+
+	res := &http.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": {"text/plain"}, "Location": {"/users/43"}},
+		Body:       io.NopCloser(strings.NewReader("created")),
+	}
+
+	for _, tc := range []struct {
+		expr openapi.RuntimeExpression
+		want any
+	}{
+		{"$url", "https://example.com/users/42?limit=10&limit=20"},
+		{"$method", "POST"},
+		{"$statusCode", 201},
+		{"$request.header.x-request-id", "abc"},
+		{"$request.query.limit", "10"},
+		{"$request.path.id", "42"},
+		{"$request.body#/user/id", openapi.Number("7")},
+		{"$request.body#/user/tags/1", "b"},
+		{"$request.body#/user", map[string]any{"id": openapi.Number("7"), "tags": []any{"a", "b"}}},
+		{"$response.header.Location", "/users/43"},
+		{"$response.body", "created"},
+		{"{$request.body#/callbackUrl}", "https://client.example.com/hook"},
+		{"{$request.body#/callbackUrl}?event=x&status={$statusCode}", "https://client.example.com/hook?event=x&status=201"},
+		{"user={$request.body#/user/tags}", `user=["a","b"]`},
+		{"constant", "constant"},
+	} {
+		t.Run(string(tc.expr), func(t *testing.T) {
+			got, err := tc.expr.Evaluate(req, res)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("want: %#v, got: %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRuntimeExpression_Evaluate_Error(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/users", strings.NewReader(`{"items": []}`))
+
+	for _, tc := range []struct {
+		expr openapi.RuntimeExpression
+		err  string
+	}{
+		{"$request.header.Accept", "$request.header.Accept: value not found"},
+		{"$request.query.limit", "$request.query.limit: value not found"},
+		{"$request.path.id", "$request.path.id: value not found"},
+		{"$request.body#/items/0", "$request.body#/items/0: value not found"},
+		{"$request.body#/missing", "$request.body#/missing: value not found"},
+		{"$statusCode", "$statusCode: no response"},
+	} {
+		t.Run(string(tc.expr), func(t *testing.T) {
+			_, err := tc.expr.Evaluate(req, nil)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package openapi
 
 import (
-	"bytes"
 	"encoding/json/jsontext"
 	"encoding/json/v2"
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"regexp"
-	"strconv"
+	"slices"
 	"strings"
 
 	"github.com/MarkRosemaker/errpath"
@@ -24,13 +26,40 @@ import (
 //
 // [Specification]: https://spec.openapis.org/oas/v3.2.0.html#schema-object
 type Schema struct {
+	// If set, this is a boolean schema: `true` allows any value, like the empty schema, and `false` allows no value at all.
+	// A boolean schema has no other fields.
+	Boolean *bool `json:"-" yaml:"-"`
+
+	// The dialect of the schema and its subschemas, overriding the `jsonSchemaDialect` of the document.
+	// Keywords that are not part of the dialect are invalid, unless the dialect keeps them as annotations in the extensions.
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+	// Identifies the schema resource with its canonical URI.
+	// It also serves as the base URI for resolving relative references within the schema.
+	// This MUST be in the form of a URI-reference and MUST NOT contain a non-empty fragment.
+	ID string `json:"$id,omitempty" yaml:"$id,omitempty"`
+	// A plain name fragment that identifies the schema within its schema resource, e.g. `Pet` to be referenced as `#Pet`.
+	Anchor string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
+	// Like `$anchor`, but it can also be the target of a `$dynamicRef`, which allows extending recursive schemas.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
+	// A reference that is resolved dynamically: if the initially resolved schema has a matching `$dynamicAnchor`,
+	// the outermost schema in the dynamic scope with the same `$dynamicAnchor` is used instead.
+	// The dynamic scope consists of the schema resources, i.e. documents and schemas with an `$id`,
+	// that were entered to reach the reference while validating an instance, see ValidateJSON.
+	DynamicRef string `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
+	// A comment for maintainers of the schema. It has no effect on validation.
+	Comment string `json:"$comment,omitempty" yaml:"$comment,omitempty"`
+	// Reusable schemas for use within this schema, e.g. to be referenced as `#/$defs/Name`.
+	Defs SchemaRefs `json:"$defs,omitzero" yaml:"$defs,omitempty"`
 	// The name of the schema.
 	Title string `json:"title,omitempty" yaml:"title,omitempty"`
 	// A short description of the schema.
 	// CommonMark syntax MAY be used for rich text representation.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
-	// Specifies the data type of the property.
-	Type DataType `json:"type,omitempty" yaml:"type,omitempty"`
+	// Specifies the data type of the property, or the data types it can have.
+	Type SchemaType `json:"type,omitzero" yaml:"type,omitempty"`
+	// Indicates whether the property can have a null value (OpenAPI 3.0).
+	// Since OpenAPI 3.1, `null` is included in `type` instead.
+	Nullable bool `json:"nullable,omitempty,omitzero" yaml:"nullable,omitempty"`
 	// Further refines the data type.
 	Format Format `json:"format,omitempty" yaml:"format,omitempty"`
 
@@ -47,21 +76,47 @@ type Schema struct {
 	// See: https://spec.openapis.org/oas/v3.2.0.html#schema-object
 	Not *SchemaRef `json:"not,omitempty" yaml:"not,omitempty"`
 
+	// If is a condition: when the value validates against it, it must also validate against `then`, otherwise against `else`.
+	// See: https://json-schema.org/draft/2020-12/json-schema-core#section-10.2.2
+	If *SchemaRef `json:"if,omitempty" yaml:"if,omitempty"`
+	// Then is applied when the value validates against `if`.
+	Then *SchemaRef `json:"then,omitempty" yaml:"then,omitempty"`
+	// Else is applied when the value does not validate against `if`.
+	Else *SchemaRef `json:"else,omitempty" yaml:"else,omitempty"`
+
+	// Adds support for polymorphism: a hint which of the schemas in oneOf or anyOf a payload is expected to validate against.
+	// See: https://spec.openapis.org/oas/v3.2.0.html#discriminator-object
+	Discriminator *Discriminator `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+
 	// Integer / Number
 
 	// The minimum value of the number.
-	Min *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Min *Number `json:"minimum,omitempty" yaml:"minimum,omitempty"`
 	// The maximum value of the number.
-	Max *float64 `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Max *Number `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	// The exclusive minimum value of the number.
+	// In OpenAPI 3.0, this is a boolean that makes `minimum` exclusive.
+	ExclusiveMin *ExclusiveBound `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	// The exclusive maximum value of the number.
+	// In OpenAPI 3.0, this is a boolean that makes `maximum` exclusive.
+	ExclusiveMax *ExclusiveBound `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	// The number must be a multiple of this value, which MUST be strictly greater than 0.
+	MultipleOf *Number `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
 
 	// String
 
+	// The minimum length of the string.
+	MinLength uint `json:"minLength,omitzero" yaml:"minLength,omitempty"`
+	// The maximum length of the string.
+	MaxLength *uint `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 	// The pattern is used to validate the string.
 	// This string SHOULD be a valid regular expression, according to the Ecma-262 Edition 5.1 regular expression dialect.
-	// NOTE: We simply use text unmarshalling for this field. This guarantees that the regular expression is valid or we can't unmarshal.
-	Pattern *regexp.Regexp `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// The pattern is compiled when unmarshaling, see CompilePattern; patterns that can't be evaluated are reported by Pattern.Unsupported.
+	Pattern *Pattern `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 	// A list of possible values. Per JSON Schema 2020-12, enum may contain any JSON type.
 	Enum []jsontext.Value `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// The only possible value. Like enum, it may be of any JSON type, including null.
+	Const jsontext.Value `json:"const,omitzero" yaml:"const,omitempty"`
 
 	// Array
 
@@ -69,104 +124,190 @@ type Schema struct {
 	MinItems uint `json:"minItems,omitzero" yaml:"minItems,omitempty"`
 	// The maximum number of items in the array.
 	MaxItems *uint `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	// The items of the array. When the type is array, this property is REQUIRED.
+	// The schemas of the first items of the array, by position, e.g. to describe a tuple.
+	PrefixItems SchemaRefList `json:"prefixItems,omitempty" yaml:"prefixItems,omitempty"`
+	// The items of the array. When the type is array, this property is REQUIRED, unless `prefixItems` describe every item the array can have.
+	// If `prefixItems` is present, this only applies to the items after those.
 	// The empty schema for `items` indicates a media type of `application/octet-stream`.
 	Items *SchemaRef `json:"items,omitzero" yaml:"items,omitempty"`
+	// The schema that at least one item of the array must validate against.
+	Contains *SchemaRef `json:"contains,omitempty" yaml:"contains,omitempty"`
+	// The minimum number of items that must validate against `contains`. Default value is `1`.
+	MinContains *uint `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+	// The maximum number of items that may validate against `contains`.
+	MaxContains *uint `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
+	// Whether the items of the array must be unique.
+	UniqueItems bool `json:"uniqueItems,omitempty,omitzero" yaml:"uniqueItems,omitempty"`
+	// The schema against which all items are validated that were not evaluated by any other keyword, including those of subschemas.
+	UnevaluatedItems *SchemaRef `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
 
 	// Object
 
 	// For object types, defines the properties of the object
 	Properties SchemaRefs `json:"properties,omitzero" yaml:"properties,omitempty"`
+	// Maps regular expressions to schemas. Each property whose name matches a regular expression must validate against the corresponding schema.
+	PatternProperties SchemaRefs `json:"patternProperties,omitzero" yaml:"patternProperties,omitempty"`
 	// Which properties are required.
 	Required             []string   `json:"required,omitempty"             yaml:"required,omitempty"`
 	AdditionalProperties *SchemaRef `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	// The schema against which each property name of the object is validated.
+	PropertyNames *SchemaRef `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
+	// The minimum number of properties of the object.
+	MinProperties uint `json:"minProperties,omitzero" yaml:"minProperties,omitempty"`
+	// The maximum number of properties of the object.
+	MaxProperties *uint `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+	// Maps a property name to the properties that are required if the property is present.
+	DependentRequired MapOfStringLists `json:"dependentRequired,omitzero" yaml:"dependentRequired,omitempty"`
+	// Maps a property name to a schema the whole object must validate against if the property is present.
+	DependentSchemas SchemaRefs `json:"dependentSchemas,omitzero" yaml:"dependentSchemas,omitempty"`
+	// The schema against which all properties are validated that were not evaluated by any other keyword, including those of subschemas.
+	UnevaluatedProperties *SchemaRef `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
 
 	// special encoding for binary data
 	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
 	ContentEncoding  string `json:"contentEncoding,omitempty"  yaml:"contentEncoding,omitempty"`
 
+	// Adds additional metadata to describe the XML representation of this schema.
+	XML *XML `json:"xml,omitempty" yaml:"xml,omitempty"`
+
 	// Specifies the default value of the property if no value is provided.
-	Default jsontext.Value `json:"default,omitempty" yaml:"default,omitempty"`
+	Default jsontext.Value `json:"default,omitzero" yaml:"default,omitempty"`
 
 	Example jsontext.Value `json:"example,omitzero" yaml:"example,omitzero"`
+	// Sample values that validate against the schema. Supersedes `example`.
+	Examples []jsontext.Value `json:"examples,omitempty" yaml:"examples,omitempty"`
+
+	// Declares the value as read only: it MAY be sent as part of a response but SHOULD NOT be sent as part of the request.
+	ReadOnly bool `json:"readOnly,omitempty,omitzero" yaml:"readOnly,omitempty"`
+	// Declares the value as write only: it MAY be sent as part of a request but SHOULD NOT be sent as part of the response.
+	WriteOnly bool `json:"writeOnly,omitempty,omitzero" yaml:"writeOnly,omitempty"`
+	// Specifies that the schema is deprecated and SHOULD be transitioned out of usage.
+	Deprecated bool `json:"deprecated,omitempty,omitzero" yaml:"deprecated,omitempty"`
+	// Additional external documentation for this schema.
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
 
 	// This object MAY be extended with Specification Extensions.
 	Extensions Extensions `json:",embed" yaml:"-"`
 
 	// an index to the original location of this object
 	idx int
+	// the schema `$dynamicRef` initially resolves to
+	dynamicRef *Schema
+	// the schema resource the schema belongs to, as determined when loading the document
+	resource *schemaResource
+	// the dialect that applies to the schema, as determined when loading the document
+	dialect *Dialect
+}
 
-	// NOTE: consider adding:
-	// Indicates whether the property can have a null value.
-	// Nullable bool `json:"nullable,omitempty,omitzero" yaml:"nullable,omitempty"`
+// schemaResource is a document or a schema with an `$id`, within which anchors are defined.
+type schemaResource struct {
+	// the schemas with a `$dynamicAnchor` in the resource, by name
+	dynamicAnchors map[string]*Schema
 }
 
+// reAnchor matches valid values of `$anchor` and `$dynamicAnchor`.
+var reAnchor = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9._]*$`)
+
 func getIndexSchema(s *Schema) int              { return s.idx }
 func setIndexSchema(s *Schema, idx int) *Schema { s.idx = idx; return s }
 
+// schemaObject has the same fields as Schema, but is always marshaled as object.
+type schemaObject Schema
+
+var _ json.MarshalerTo = (*Schema)(nil)
+
+// MarshalJSONTo marshals the schema as boolean, if it is a boolean schema, and as object otherwise.
+func (s *Schema) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if s.Boolean != nil {
+		return enc.WriteToken(jsontext.Bool(*s.Boolean))
+	}
+
+	return json.MarshalEncode(enc, (*schemaObject)(s))
+}
+
+var _ json.UnmarshalerFrom = (*Schema)(nil)
+
+// UnmarshalJSONFrom unmarshals the schema from either a boolean or an object.
+func (s *Schema) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	switch dec.PeekKind() {
+	case 't', 'f':
+		*s = Schema{Boolean: new(bool)}
+		return json.UnmarshalDecode(dec, s.Boolean)
+	default:
+		return json.UnmarshalDecode(dec, (*schemaObject)(s))
+	}
+}
+
 func (s *Schema) Validate() error {
+	if s.Boolean != nil {
+		return nil
+	}
+
 	s.Description = strings.TrimSpace(s.Description)
 
-	if s.Type == "" {
-		if len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 && s.Not == nil {
-			return &errpath.ErrField{Field: "type", Err: &errpath.ErrRequired{}}
+	if s.Schema != "" {
+		if err := validateDialect(s.Schema); err != nil {
+			return &errpath.ErrField{Field: "$schema", Err: err}
 		}
-	} else if err := s.Type.Validate(); err != nil {
-		return &errpath.ErrField{Field: "type", Err: err}
 	}
 
-	if s.Format != "" {
-		if err := s.Format.Validate(); err != nil {
-			return &errpath.ErrField{Field: "format", Err: err}
+	if s.ID != "" {
+		if u, err := url.Parse(s.ID); err != nil {
+			return &errpath.ErrField{Field: "$id", Err: err}
+		} else if u.Fragment != "" {
+			return &errpath.ErrField{Field: "$id", Err: &errpath.ErrInvalid[string]{
+				Value:   s.ID,
+				Message: "must not contain a non-empty fragment",
+			}}
 		}
 	}
 
-	// validate if format is valid for type
-	switch s.Format {
-	case "": // no format
-	case FormatInt32, FormatInt64, FormatUint, FormatUint32, FormatUint64:
-		if s.Type != TypeInteger {
-			return &errpath.ErrField{Field: "format", Err: &errpath.ErrInvalid[Format]{
-				Value:   s.Format,
-				Message: fmt.Sprintf("only valid for integer type, got %s", s.Type),
+	for _, anchor := range []struct{ field, value string }{
+		{"$anchor", s.Anchor},
+		{"$dynamicAnchor", s.DynamicAnchor},
+	} {
+		if anchor.value != "" && !reAnchor.MatchString(anchor.value) {
+			return &errpath.ErrField{Field: anchor.field, Err: &errpath.ErrInvalid[string]{
+				Value:   anchor.value,
+				Message: fmt.Sprintf("must match the regular expression %q", reAnchor),
 			}}
 		}
-	case FormatFloat, FormatDouble:
-		if s.Type != TypeNumber {
-			return &errpath.ErrField{Field: "format", Err: &errpath.ErrInvalid[Format]{
-				Value:   s.Format,
-				Message: fmt.Sprintf("only valid for number type, got %s", s.Type),
-			}}
+	}
+
+	if s.DynamicRef != "" {
+		if _, err := url.Parse(s.DynamicRef); err != nil {
+			return &errpath.ErrField{Field: "$dynamicRef", Err: err}
 		}
-	case FormatEmail, FormatPassword,
-		FormatUUID, FormatURI, FormatURIRef, FormatZipCode,
-		FormatIPv4, FormatIPv6:
-		if s.Type != TypeString {
-			return &errpath.ErrField{Field: "format", Err: &errpath.ErrInvalid[Format]{
-				Value:   s.Format,
-				Message: fmt.Sprintf("only valid for string type, got %s", s.Type),
-			}}
+	}
+
+	if err := s.Defs.Validate(); err != nil {
+		return &errpath.ErrField{Field: "$defs", Err: err}
+	}
+
+	if s.Type.IsZero() {
+		// the type may be omitted if it is given by subschemas, by `const` or by the target of `$dynamicRef`,
+		// or if the schema merely holds definitions
+		if len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 && s.Not == nil &&
+			s.If == nil && s.Const == nil && s.DynamicRef == "" && (s.Defs == nil || !s.isEmpty()) {
+			return &errpath.ErrField{Field: "type", Err: &errpath.ErrRequired{}}
 		}
-	case FormatDuration, FormatDate, FormatDateTime:
-		switch s.Type {
-		case TypeInteger, TypeString:
-		default:
-			return &errpath.ErrField{Field: "format", Err: &errpath.ErrInvalid[Format]{
-				Value:   s.Format,
-				Message: fmt.Sprintf("only valid for integer or string type, got %s", s.Type),
+
+		// in OpenAPI 3.0, nullable only adds null to an explicitly defined type
+		if s.Nullable {
+			return &errpath.ErrField{Field: "nullable", Err: &errpath.ErrInvalid[bool]{
+				Value:   true,
+				Message: "property has no effect when type is not present",
 			}}
 		}
-	case FormatByte, FormatBinary:
-		switch s.Type {
-		case TypeString:
-		default:
-			return &errpath.ErrField{Field: "format", Err: &errpath.ErrInvalid[Format]{
-				Value:   s.Format,
-				Message: fmt.Sprintf("only valid for string type, got %s", s.Type),
-			}}
+	} else if err := s.Type.Validate(); err != nil {
+		return &errpath.ErrField{Field: "type", Err: err}
+	}
+
+	// unknown formats are tolerated, see Document.Warnings
+	if s.Format != "" {
+		if err := s.Format.validateType(s.Type); err != nil {
+			return &errpath.ErrField{Field: "format", Err: err}
 		}
-	default:
-		return fmt.Errorf("unimplemented format: %s", s.Format)
 	}
 
 	for i, v := range s.AllOf {
@@ -202,62 +343,179 @@ func (s *Schema) Validate() error {
 		}
 	}
 
+	if s.If != nil {
+		if err := s.If.Validate(); err != nil {
+			return &errpath.ErrField{Field: "if", Err: err}
+		}
+
+		if s.Then != nil {
+			if err := s.Then.Validate(); err != nil {
+				return &errpath.ErrField{Field: "then", Err: err}
+			}
+		}
+
+		if s.Else != nil {
+			if err := s.Else.Validate(); err != nil {
+				return &errpath.ErrField{Field: "else", Err: err}
+			}
+		}
+	} else if s.Then != nil {
+		return &errpath.ErrField{Field: "then", Err: &errpath.ErrInvalid[string]{
+			Message: "property has no effect when if is not present",
+		}}
+	} else if s.Else != nil {
+		return &errpath.ErrField{Field: "else", Err: &errpath.ErrInvalid[string]{
+			Message: "property has no effect when if is not present",
+		}}
+	}
+
+	if s.Discriminator != nil {
+		if err := s.Discriminator.Validate(); err != nil {
+			return &errpath.ErrField{Field: "discriminator", Err: err}
+		}
+
+		if err := s.validateDiscriminator(); err != nil {
+			return err
+		}
+	}
+
 	// Integer / Number
 
 	// validate min and max
-	if s.Type == TypeInteger {
-		if s.Min != nil && *s.Min != float64(int(*s.Min)) {
-			return &errpath.ErrField{Field: "minimum", Err: &errpath.ErrInvalid[float64]{
+	if s.Type.Includes(TypeInteger) && !s.Type.Includes(TypeNumber) {
+		if s.Min != nil && !s.Min.IsInteger() {
+			return &errpath.ErrField{Field: "minimum", Err: &errpath.ErrInvalid[Number]{
 				Value:   *s.Min,
 				Message: "not an integer",
 			}}
 		}
 
-		if s.Max != nil && *s.Max != float64(int(*s.Max)) {
-			return &errpath.ErrField{Field: "maximum", Err: &errpath.ErrInvalid[float64]{
+		if s.Max != nil && !s.Max.IsInteger() {
+			return &errpath.ErrField{Field: "maximum", Err: &errpath.ErrInvalid[Number]{
 				Value:   *s.Max,
 				Message: "not an integer",
 			}}
 		}
 	}
 
-	if s.Type == TypeNumber || s.Type == TypeInteger {
-		if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
-			return &errpath.ErrField{Field: "minimum", Err: &errpath.ErrInvalid[float64]{
+	if s.Type.Includes(TypeNumber) || s.Type.Includes(TypeInteger) {
+		if s.Min != nil && s.Max != nil && s.Min.Cmp(*s.Max) > 0 {
+			return &errpath.ErrField{Field: "minimum", Err: &errpath.ErrInvalid[Number]{
 				Value:   *s.Min,
 				Message: fmt.Sprintf("minimum is greater than maximum (%v > %v)", *s.Min, *s.Max),
 			}}
 		}
+
+		// in OpenAPI 3.0, the boolean form modifies minimum or maximum, which therefore must be present
+		if s.ExclusiveMin != nil && s.ExclusiveMin.Number == nil && s.ExclusiveMin.Bool && s.Min == nil {
+			return &errpath.ErrField{Field: "exclusiveMinimum", Err: &errpath.ErrInvalid[bool]{
+				Value:   true,
+				Message: "minimum is required",
+			}}
+		}
+
+		if s.ExclusiveMax != nil && s.ExclusiveMax.Number == nil && s.ExclusiveMax.Bool && s.Max == nil {
+			return &errpath.ErrField{Field: "exclusiveMaximum", Err: &errpath.ErrInvalid[bool]{
+				Value:   true,
+				Message: "maximum is required",
+			}}
+		}
+
+		if s.MultipleOf != nil && s.MultipleOf.Sign() <= 0 {
+			return &errpath.ErrField{Field: "multipleOf", Err: &errpath.ErrInvalid[Number]{
+				Value:   *s.MultipleOf,
+				Message: "must be greater than 0",
+			}}
+		}
 	} else if s.Min != nil {
-		return &errpath.ErrField{Field: "minimum", Err: &errpath.ErrInvalid[float64]{
+		return &errpath.ErrField{Field: "minimum", Err: &errpath.ErrInvalid[Number]{
 			Value:   *s.Min,
 			Message: fmt.Sprintf("only valid for number type, got %s", s.Type),
 		}}
 	} else if s.Max != nil {
-		return &errpath.ErrField{Field: "maximum", Err: &errpath.ErrInvalid[float64]{
+		return &errpath.ErrField{Field: "maximum", Err: &errpath.ErrInvalid[Number]{
 			Value:   *s.Max,
 			Message: fmt.Sprintf("only valid for number type, got %s", s.Type),
 		}}
+	} else if s.ExclusiveMin != nil {
+		return &errpath.ErrField{Field: "exclusiveMinimum", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for number type, got %s", s.Type),
+		}}
+	} else if s.ExclusiveMax != nil {
+		return &errpath.ErrField{Field: "exclusiveMaximum", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for number type, got %s", s.Type),
+		}}
+	} else if s.MultipleOf != nil {
+		return &errpath.ErrField{Field: "multipleOf", Err: &errpath.ErrInvalid[Number]{
+			Value:   *s.MultipleOf,
+			Message: fmt.Sprintf("only valid for number type, got %s", s.Type),
+		}}
 	}
 
-	// String / Enum
+	// String
+
+	// validate min and max length
+	if s.Type.Includes(TypeString) {
+		if s.MaxLength != nil && s.MinLength > *s.MaxLength {
+			return &errpath.ErrField{Field: "minLength", Err: &errpath.ErrInvalid[uint]{
+				Value:   s.MinLength,
+				Message: fmt.Sprintf("minLength is greater than maxLength (%d > %d)", s.MinLength, *s.MaxLength),
+			}}
+		}
+	} else if s.MinLength != 0 {
+		return &errpath.ErrField{Field: "minLength", Err: &errpath.ErrInvalid[uint]{
+			Value:   s.MinLength,
+			Message: fmt.Sprintf("only valid for string type, got %s", s.Type),
+		}}
+	} else if s.MaxLength != nil {
+		return &errpath.ErrField{Field: "maxLength", Err: &errpath.ErrInvalid[uint]{
+			Value:   *s.MaxLength,
+			Message: fmt.Sprintf("only valid for string type, got %s", s.Type),
+		}}
+	}
+
+	if s.Pattern != nil {
+		if err := s.Pattern.Validate(); err != nil {
+			return &errpath.ErrField{Field: "pattern", Err: err}
+		}
+	}
+
+	// Enum
 
 	// Per JSON Schema 2020-12, enum can hold any JSON type; validate each value's kind matches the schema type.
-	if s.Type != "" {
-		for i, ev := range s.Enum {
-			if !enumKindMatchesType(ev, s.Type) {
-				return &errpath.ErrField{Field: "enum", Err: &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[any]{
-					Value:   jsonDisplayValue(ev),
-					Message: fmt.Sprintf("must be a %s value", s.Type),
-				}}}
-			}
+	for i, ev := range s.Enum {
+		if !s.allowsKindOf(ev) {
+			return &errpath.ErrField{Field: "enum", Err: &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[any]{
+				Value:   jsonDisplayValue(ev),
+				Message: fmt.Sprintf("must be a %s value", s.Type),
+			}}}
+		}
+	}
+
+	// Const
+
+	if s.Const != nil {
+		if !s.allowsKindOf(s.Const) {
+			return &errpath.ErrField{Field: "const", Err: &errpath.ErrInvalid[any]{
+				Value:   jsonDisplayValue(s.Const),
+				Message: fmt.Sprintf("must be a %s value", s.Type),
+			}}
+		}
+
+		if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(ev jsontext.Value) bool {
+			return jsonEqual(ev, s.Const)
+		}) {
+			return &errpath.ErrField{Field: "const", Err: &errpath.ErrInvalid[any]{
+				Value:   jsonDisplayValue(s.Const),
+				Message: fmt.Sprintf("is not one of the enums (%s)", enumString(s.Enum)),
+			}}
 		}
 	}
 
 	// Array
 
 	// validate min and max items
-	if s.Type == TypeArray {
+	if s.Type.Includes(TypeArray) {
 		if s.MaxItems != nil && s.MinItems > *s.MaxItems {
 			return &errpath.ErrField{Field: "minItems", Err: &errpath.ErrInvalid[uint]{
 				Value:   s.MinItems,
@@ -265,16 +523,55 @@ func (s *Schema) Validate() error {
 			}}
 		}
 
-		if s.Items == nil {
-			return &errpath.ErrField{Field: "items", Err: &errpath.ErrRequired{}}
+		for i, v := range s.PrefixItems {
+			if err := v.Validate(); err != nil {
+				return &errpath.ErrField{
+					Field: "prefixItems",
+					Err:   &errpath.ErrIndex{Index: i, Err: err},
+				}
+			}
 		}
 
-		// empty schema for items indicates a media type of application/octet-stream.
-		if !s.Items.Value.isEmpty() {
+		if s.Items == nil {
+			// items may be omitted if prefixItems describe every item the array can have
+			if len(s.PrefixItems) == 0 || s.MaxItems == nil || *s.MaxItems > uint(len(s.PrefixItems)) {
+				return &errpath.ErrField{Field: "items", Err: &errpath.ErrRequired{}}
+			}
+		} else if !s.Items.Value.isEmpty() {
+			// empty schema for items indicates a media type of application/octet-stream.
 			if err := s.Items.Validate(); err != nil {
 				return &errpath.ErrField{Field: "items", Err: err}
 			}
 		}
+
+		if s.Contains != nil {
+			if err := s.Contains.Validate(); err != nil {
+				return &errpath.ErrField{Field: "contains", Err: err}
+			}
+
+			if s.MinContains != nil && s.MaxContains != nil && *s.MinContains > *s.MaxContains {
+				return &errpath.ErrField{Field: "minContains", Err: &errpath.ErrInvalid[uint]{
+					Value:   *s.MinContains,
+					Message: fmt.Sprintf("minContains is greater than maxContains (%d > %d)", *s.MinContains, *s.MaxContains),
+				}}
+			}
+		} else if s.MinContains != nil {
+			return &errpath.ErrField{Field: "minContains", Err: &errpath.ErrInvalid[uint]{
+				Value:   *s.MinContains,
+				Message: "property has no effect when contains is not present",
+			}}
+		} else if s.MaxContains != nil {
+			return &errpath.ErrField{Field: "maxContains", Err: &errpath.ErrInvalid[uint]{
+				Value:   *s.MaxContains,
+				Message: "property has no effect when contains is not present",
+			}}
+		}
+
+		if s.UnevaluatedItems != nil {
+			if err := s.UnevaluatedItems.Validate(); err != nil {
+				return &errpath.ErrField{Field: "unevaluatedItems", Err: err}
+			}
+		}
 	} else if s.MinItems != 0 {
 		return &errpath.ErrField{Field: "minItems", Err: &errpath.ErrInvalid[uint]{
 			Value:   s.MinItems,
@@ -285,24 +582,72 @@ func (s *Schema) Validate() error {
 			Value:   *s.MaxItems,
 			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
 		}}
+	} else if s.PrefixItems != nil {
+		return &errpath.ErrField{Field: "prefixItems", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+		}}
 	} else if s.Items != nil {
 		return &errpath.ErrField{Field: "items", Err: &errpath.ErrInvalid[string]{
 			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
 		}}
+	} else if s.Contains != nil {
+		return &errpath.ErrField{Field: "contains", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+		}}
+	} else if s.MinContains != nil {
+		return &errpath.ErrField{Field: "minContains", Err: &errpath.ErrInvalid[uint]{
+			Value:   *s.MinContains,
+			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+		}}
+	} else if s.MaxContains != nil {
+		return &errpath.ErrField{Field: "maxContains", Err: &errpath.ErrInvalid[uint]{
+			Value:   *s.MaxContains,
+			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+		}}
+	} else if s.UniqueItems {
+		return &errpath.ErrField{Field: "uniqueItems", Err: &errpath.ErrInvalid[bool]{
+			Value:   true,
+			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+		}}
+	} else if s.UnevaluatedItems != nil {
+		return &errpath.ErrField{Field: "unevaluatedItems", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+		}}
 	}
 
 	// Object
 
-	if s.Type == TypeObject {
+	if s.Type.Includes(TypeObject) {
 		if err := s.Properties.Validate(); err != nil {
 			return &errpath.ErrField{Field: "properties", Err: err}
 		}
 
+		patterns := make([]*Pattern, 0, len(s.PatternProperties))
+		for pattern, p := range s.PatternProperties.ByIndex() {
+			re, err := CompilePattern(pattern)
+			if err != nil {
+				return &errpath.ErrField{Field: "patternProperties", Err: &errpath.ErrKey{
+					Key: pattern,
+					Err: &errpath.ErrInvalid[string]{Value: pattern, Message: err.Error()},
+				}}
+			}
+
+			if err := p.Validate(); err != nil {
+				return &errpath.ErrField{Field: "patternProperties", Err: &errpath.ErrKey{Key: pattern, Err: err}}
+			}
+
+			patterns = append(patterns, re)
+		}
+
 		for i, r := range s.Required {
 			if _, ok := s.Properties[r]; ok {
 				continue
 			}
 
+			if matchesAny(patterns, r) {
+				continue
+			}
+
 			return &errpath.ErrField{
 				Field: "required",
 				Err: &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[string]{
@@ -317,78 +662,175 @@ func (s *Schema) Validate() error {
 				return &errpath.ErrField{Field: "additionalProperties", Err: err}
 			}
 		}
+
+		if s.PropertyNames != nil {
+			if err := s.PropertyNames.Validate(); err != nil {
+				return &errpath.ErrField{Field: "propertyNames", Err: err}
+			}
+
+			// property names are always strings
+			if t := s.PropertyNames.Value.Type; !t.IsZero() && !t.Includes(TypeString) {
+				return &errpath.ErrField{Field: "propertyNames", Err: &errpath.ErrField{
+					Field: "type",
+					Err: &errpath.ErrInvalid[string]{
+						Value:   t.String(),
+						Message: "property names are strings",
+					},
+				}}
+			}
+		}
+
+		if s.MaxProperties != nil && s.MinProperties > *s.MaxProperties {
+			return &errpath.ErrField{Field: "minProperties", Err: &errpath.ErrInvalid[uint]{
+				Value:   s.MinProperties,
+				Message: fmt.Sprintf("minProperties is greater than maxProperties (%d > %d)", s.MinProperties, *s.MaxProperties),
+			}}
+		}
+
+		for name, deps := range s.DependentRequired.ByIndex() {
+			for i, dep := range deps.Values {
+				if slices.Contains(deps.Values[:i], dep) {
+					return &errpath.ErrField{Field: "dependentRequired", Err: &errpath.ErrKey{
+						Key: name,
+						Err: &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[string]{
+							Value:   dep,
+							Message: "must be unique",
+						}},
+					}}
+				}
+			}
+		}
+
+		if err := s.DependentSchemas.Validate(); err != nil {
+			return &errpath.ErrField{Field: "dependentSchemas", Err: err}
+		}
+
+		if s.UnevaluatedProperties != nil {
+			if err := s.UnevaluatedProperties.Validate(); err != nil {
+				return &errpath.ErrField{Field: "unevaluatedProperties", Err: err}
+			}
+		}
 	} else if s.Properties != nil {
 		return &errpath.ErrField{Field: "properties", Err: &errpath.ErrInvalid[string]{
 			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
 		}}
+	} else if s.PatternProperties != nil {
+		return &errpath.ErrField{Field: "patternProperties", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
 	} else if s.AdditionalProperties != nil {
 		return &errpath.ErrField{Field: "additionalProperties", Err: &errpath.ErrInvalid[string]{
 			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
 		}}
+	} else if s.PropertyNames != nil {
+		return &errpath.ErrField{Field: "propertyNames", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
+	} else if s.MinProperties != 0 {
+		return &errpath.ErrField{Field: "minProperties", Err: &errpath.ErrInvalid[uint]{
+			Value:   s.MinProperties,
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
+	} else if s.MaxProperties != nil {
+		return &errpath.ErrField{Field: "maxProperties", Err: &errpath.ErrInvalid[uint]{
+			Value:   *s.MaxProperties,
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
+	} else if s.DependentRequired != nil {
+		return &errpath.ErrField{Field: "dependentRequired", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
+	} else if s.DependentSchemas != nil {
+		return &errpath.ErrField{Field: "dependentSchemas", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
+	} else if s.UnevaluatedProperties != nil {
+		return &errpath.ErrField{Field: "unevaluatedProperties", Err: &errpath.ErrInvalid[string]{
+			Message: fmt.Sprintf("only valid for object type, got %s", s.Type),
+		}}
+	}
+
+	if s.ReadOnly && s.WriteOnly {
+		return errors.New("readOnly and writeOnly are mutually exclusive")
+	}
+
+	if s.ExternalDocs != nil {
+		if err := s.ExternalDocs.Validate(); err != nil {
+			return &errpath.ErrField{Field: "externalDocs", Err: err}
+		}
+	}
+
+	if s.XML != nil {
+		if err := s.XML.Validate(); err != nil {
+			return &errpath.ErrField{Field: "xml", Err: err}
+		}
+
+		if s.XML.Wrapped && !s.Type.Includes(TypeArray) {
+			return &errpath.ErrField{Field: "xml", Err: &errpath.ErrField{
+				Field: "wrapped",
+				Err: &errpath.ErrInvalid[bool]{
+					Value:   true,
+					Message: fmt.Sprintf("only valid for array type, got %s", s.Type),
+				},
+			}}
+		}
 	}
 
 	// validate default
 	if len(s.Default) > 0 {
-		defaultTypeErr := func() error {
+		if !s.allowsKindOf(s.Default) {
 			return &errpath.ErrField{Field: "default", Err: &errpath.ErrInvalid[any]{
 				Value:   jsonDisplayValue(s.Default),
 				Message: fmt.Sprintf("does not match schema type, got %s", s.Type),
 			}}
 		}
 
-		switch s.Type {
-		case TypeString:
-			if s.Default.Kind() != jsontext.KindString {
-				return defaultTypeErr()
-			}
-		case TypeNumber:
-			if s.Default.Kind() != jsontext.KindNumber {
-				return defaultTypeErr()
-			}
-		case TypeInteger:
-			if s.Default.Kind() != jsontext.KindNumber || !isJSONInteger(s.Default) {
-				return defaultTypeErr()
-			}
-		case TypeBoolean:
-			if s.Default.Kind() != jsontext.KindTrue && s.Default.Kind() != jsontext.KindFalse {
-				return defaultTypeErr()
-			}
-		case TypeArray:
-			if s.Default.Kind() != jsontext.KindBeginArray {
-				return defaultTypeErr()
-			}
-		case TypeObject:
-			if s.Default.Kind() != jsontext.KindBeginObject {
-				return defaultTypeErr()
-			}
-		case TypeNull:
-			if s.Default.Kind() != jsontext.KindNull {
-				return defaultTypeErr()
-			}
+		if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(ev jsontext.Value) bool {
+			return jsonEqual(ev, s.Default)
+		}) {
+			return &errpath.ErrField{Field: "default", Err: &errpath.ErrInvalid[any]{
+				Value:   jsonDisplayValue(s.Default),
+				Message: fmt.Sprintf("is not one of the enums (%s)", enumString(s.Enum)),
+			}}
 		}
+	}
 
-		if len(s.Enum) > 0 {
-			found := false
-			for _, ev := range s.Enum {
-				if bytes.Equal(ev, s.Default) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				parts := make([]string, len(s.Enum))
-				for i, ev := range s.Enum {
-					parts[i] = ev.String()
-				}
-				return &errpath.ErrField{Field: "default", Err: &errpath.ErrInvalid[any]{
-					Value:   jsonDisplayValue(s.Default),
-					Message: fmt.Sprintf("is not one of the enums ([%s])", strings.Join(parts, " ")),
-				}}
-			}
+	return s.getDialect().validateKeywords(s.Extensions)
+}
+
+// getDialect returns the dialect that applies to the schema.
+func (s *Schema) getDialect() *Dialect {
+	if s.Schema != "" {
+		if d, ok := LookupDialect(s.Schema); ok {
+			return d
 		}
 	}
 
-	return nil
+	if s.dialect != nil {
+		return s.dialect
+	}
+
+	d, _ := LookupDialect(DialectOASBase)
+	return d
+}
+
+// IsNullable reports whether the value can be null,
+// either because `type` includes `null` or, in OpenAPI 3.0, because `nullable` is true.
+func (s *Schema) IsNullable() bool { return s.Nullable || s.Type.Includes(TypeNull) }
+
+// allowsKindOf reports whether the kind of a JSON value is compatible with the schema type, considering `nullable`.
+func (s *Schema) allowsKindOf(v jsontext.Value) bool {
+	return s.Type.includesKindOf(v) || (s.Nullable && v.Kind() == jsontext.KindNull)
+}
+
+// enumString formats enum values for error messages, e.g. `["a" "b"]`.
+func enumString(enum []jsontext.Value) string {
+	parts := make([]string, len(enum))
+	for i, ev := range enum {
+		parts[i] = ev.String()
+	}
+
+	return "[" + strings.Join(parts, " ") + "]"
 }
 
 // enumKindMatchesType reports whether a JSON value's kind is compatible with the given DataType.
@@ -416,8 +858,7 @@ func enumKindMatchesType(v jsontext.Value, t DataType) bool {
 
 // isJSONInteger reports whether a JSON number value represents a whole number.
 func isJSONInteger(v jsontext.Value) bool {
-	f, err := strconv.ParseFloat(string(v), 64)
-	return err == nil && f == float64(int64(f))
+	return Number(v).IsInteger()
 }
 
 // jsonDisplayValue converts a jsontext.Value to a typed Go value suitable for
@@ -430,10 +871,7 @@ func jsonDisplayValue(v jsontext.Value) any {
 			return s
 		}
 	case jsontext.KindNumber:
-		var f float64
-		if err := json.Unmarshal(v, &f); err == nil {
-			return f
-		}
+		return Number(v)
 	case jsontext.KindTrue:
 		return true
 	case jsontext.KindFalse:
@@ -442,15 +880,152 @@ func jsonDisplayValue(v jsontext.Value) any {
 	return string(v)
 }
 
+func (l *loader) collectSchemaRef(s *SchemaRef, ref ref) {
+	if s.Ref == nil && s.Value != nil {
+		l.collectSchema(s.Value, ref)
+	}
+}
+
+// aliasSchemas makes the schemas collected below one key available below another key as well.
+func (l *loader) aliasSchemas(from, to string) {
+	aliases := map[string]*Schema{}
+	for key, s := range l.schemas {
+		if rest, ok := strings.CutPrefix(key, from+"/"); ok {
+			aliases[to+"/"+rest] = s
+		}
+	}
+
+	maps.Copy(l.schemas, aliases)
+}
+
 func (l *loader) collectSchema(s *Schema, ref ref) {
 	l.schemas[ref.String()] = s // collect this schema
+
+	// a schema with an `$id` is a schema resource of its own,
+	// so it and its subschemas can also be referenced relative to that URI
+	if s.ID != "" {
+		if u, err := resolveURIAgainst(ref[0], s.ID); err == nil {
+			// the subschemas can still be referenced by their location in the document
+			defer l.aliasSchemas(refKey(u), ref.String())
+
+			ref = []string{refKey(u)}
+			l.schemas[ref.String()] = s
+		}
+	}
+
+	// anchors identify the schema by a plain name fragment within its schema resource
+	if s.Anchor != "" {
+		l.schemas[ref[0]+s.Anchor] = s
+	}
+
+	s.resource = l.resources[ref[0]]
+	if s.resource == nil {
+		s.resource = &schemaResource{dynamicAnchors: map[string]*Schema{}}
+		l.resources[ref[0]] = s.resource
+	}
+
+	if s.DynamicAnchor != "" {
+		l.schemas[ref[0]+s.DynamicAnchor] = s
+		s.resource.dynamicAnchors[s.DynamicAnchor] = s
+	}
+
+	l.collectSchemaRefs(s.Defs, append(ref, "$defs"))
+
+	l.collectSchemaRefList(s.AllOf, append(ref, "allOf"))
+	l.collectSchemaRefList(s.OneOf, append(ref, "oneOf"))
+	l.collectSchemaRefList(s.AnyOf, append(ref, "anyOf"))
+
+	if s.Not != nil {
+		l.collectSchemaRef(s.Not, append(ref, "not"))
+	}
+
+	if s.If != nil {
+		l.collectSchemaRef(s.If, append(ref, "if"))
+	}
+
+	if s.Then != nil {
+		l.collectSchemaRef(s.Then, append(ref, "then"))
+	}
+
+	if s.Else != nil {
+		l.collectSchemaRef(s.Else, append(ref, "else"))
+	}
+
+	l.collectSchemaRefList(s.PrefixItems, append(ref, "prefixItems"))
+
+	if s.Items != nil {
+		l.collectSchemaRef(s.Items, append(ref, "items"))
+	}
+
+	if s.Contains != nil {
+		l.collectSchemaRef(s.Contains, append(ref, "contains"))
+	}
+
+	if s.UnevaluatedItems != nil {
+		l.collectSchemaRef(s.UnevaluatedItems, append(ref, "unevaluatedItems"))
+	}
+
+	l.collectSchemaRefs(s.Properties, append(ref, "properties"))
+	l.collectSchemaRefs(s.PatternProperties, append(ref, "patternProperties"))
+
+	if s.AdditionalProperties != nil {
+		l.collectSchemaRef(s.AdditionalProperties, append(ref, "additionalProperties"))
+	}
+
+	if s.PropertyNames != nil {
+		l.collectSchemaRef(s.PropertyNames, append(ref, "propertyNames"))
+	}
+
+	l.collectSchemaRefs(s.DependentSchemas, append(ref, "dependentSchemas"))
+
+	if s.UnevaluatedProperties != nil {
+		l.collectSchemaRef(s.UnevaluatedProperties, append(ref, "unevaluatedProperties"))
+	}
 }
 
 func (l *loader) resolveSchemaRef(s *SchemaRef) error {
-	return resolveRef(s, l.schemas, l.resolveSchema)
+	return resolveRef(l, s, l.schemas, l.resolveSchema)
 }
 
 func (l *loader) resolveSchema(s *Schema) error {
+	// `$id` sets the base URI for relative references within the schema
+	if s.ID != "" {
+		base := l.base
+		defer func() { l.base = base }()
+
+		u, err := l.resolveURI(s.ID)
+		if err != nil {
+			return &errpath.ErrField{Field: "$id", Err: err}
+		}
+
+		l.base = u
+	}
+
+	// `$schema` sets the dialect of the schema and its subschemas
+	if s.Schema != "" {
+		if d, ok := LookupDialect(s.Schema); ok {
+			dialect := l.dialect
+			defer func() { l.dialect = dialect }()
+
+			l.dialect = d
+		}
+	}
+
+	s.dialect = l.dialect
+
+	if s.DynamicRef != "" {
+		target, err := l.lookupSchemaURI(s.DynamicRef)
+		if err != nil {
+			return &errpath.ErrField{Field: "$dynamicRef", Err: err}
+		}
+
+		s.dynamicRef = target
+	}
+
+	if err := l.resolveSchemaRefs(s.Defs); err != nil {
+		return &errpath.ErrField{Field: "$defs", Err: err}
+	}
+
 	if err := l.resolveSchemaRefList(s.AllOf); err != nil {
 		return &errpath.ErrField{Field: "allOf", Err: err}
 	}
@@ -469,34 +1044,108 @@ func (l *loader) resolveSchema(s *Schema) error {
 		}
 	}
 
+	if s.Discriminator != nil {
+		if err := l.resolveDiscriminator(s.Discriminator); err != nil {
+			return &errpath.ErrField{Field: "discriminator", Err: err}
+		}
+	}
+
+	if s.If != nil {
+		if err := l.resolveSchemaRef(s.If); err != nil {
+			return &errpath.ErrField{Field: "if", Err: err}
+		}
+	}
+
+	if s.Then != nil {
+		if err := l.resolveSchemaRef(s.Then); err != nil {
+			return &errpath.ErrField{Field: "then", Err: err}
+		}
+	}
+
+	if s.Else != nil {
+		if err := l.resolveSchemaRef(s.Else); err != nil {
+			return &errpath.ErrField{Field: "else", Err: err}
+		}
+	}
+
+	if err := l.resolveSchemaRefList(s.PrefixItems); err != nil {
+		return &errpath.ErrField{Field: "prefixItems", Err: err}
+	}
+
 	if s.Items != nil {
 		if err := l.resolveSchemaRef(s.Items); err != nil {
 			return &errpath.ErrField{Field: "items", Err: err}
 		}
 	}
 
+	if s.Contains != nil {
+		if err := l.resolveSchemaRef(s.Contains); err != nil {
+			return &errpath.ErrField{Field: "contains", Err: err}
+		}
+	}
+
+	if s.UnevaluatedItems != nil {
+		if err := l.resolveSchemaRef(s.UnevaluatedItems); err != nil {
+			return &errpath.ErrField{Field: "unevaluatedItems", Err: err}
+		}
+	}
+
 	if err := l.resolveSchemaRefs(s.Properties); err != nil {
 		return &errpath.ErrField{Field: "properties", Err: err}
 	}
 
+	if err := l.resolveSchemaRefs(s.PatternProperties); err != nil {
+		return &errpath.ErrField{Field: "patternProperties", Err: err}
+	}
+
 	if s.AdditionalProperties != nil {
 		if err := l.resolveSchemaRef(s.AdditionalProperties); err != nil {
 			return &errpath.ErrField{Field: "additionalProperties", Err: err}
 		}
 	}
 
+	if s.PropertyNames != nil {
+		if err := l.resolveSchemaRef(s.PropertyNames); err != nil {
+			return &errpath.ErrField{Field: "propertyNames", Err: err}
+		}
+	}
+
+	if err := l.resolveSchemaRefs(s.DependentSchemas); err != nil {
+		return &errpath.ErrField{Field: "dependentSchemas", Err: err}
+	}
+
+	if s.UnevaluatedProperties != nil {
+		if err := l.resolveSchemaRef(s.UnevaluatedProperties); err != nil {
+			return &errpath.ErrField{Field: "unevaluatedProperties", Err: err}
+		}
+	}
+
 	return nil
 }
 
 func (s *Schema) isEmpty() bool {
+	if s != nil && s.Boolean != nil {
+		// the `true` schema is equivalent to the empty schema
+		return *s.Boolean
+	}
+
 	return s == nil ||
-		(s.Type == "" && s.Format == "" &&
+		(s.Type.IsZero() && !s.Nullable && s.Format == "" &&
 			len(s.AllOf) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 && s.Not == nil &&
+			s.If == nil && s.Then == nil && s.Else == nil && s.Discriminator == nil &&
 			s.Min == nil && s.Max == nil &&
-			s.Pattern == nil &&
-			s.MinItems == 0 && s.MaxItems == nil && s.Items == nil &&
-			s.Properties == nil && s.Required == nil &&
-			s.AdditionalProperties == nil &&
-			s.ContentMediaType == "" && s.ContentEncoding == "" &&
-			s.Example == nil)
+			s.ExclusiveMin == nil && s.ExclusiveMax == nil && s.MultipleOf == nil &&
+			s.MinLength == 0 && s.MaxLength == nil && s.Pattern == nil &&
+			s.Const == nil &&
+			s.MinItems == 0 && s.MaxItems == nil && s.PrefixItems == nil && s.Items == nil &&
+			s.Contains == nil && s.MinContains == nil && s.MaxContains == nil &&
+			!s.UniqueItems && s.UnevaluatedItems == nil &&
+			s.Properties == nil && s.PatternProperties == nil && s.Required == nil &&
+			s.AdditionalProperties == nil && s.PropertyNames == nil &&
+			s.MinProperties == 0 && s.MaxProperties == nil &&
+			s.DependentRequired == nil && s.DependentSchemas == nil &&
+			s.UnevaluatedProperties == nil &&
+			s.ContentMediaType == "" && s.ContentEncoding == "" && s.XML == nil &&
+			s.Example == nil && s.Examples == nil &&
+			!s.ReadOnly && !s.WriteOnly && !s.Deprecated && s.ExternalDocs == nil)
 }
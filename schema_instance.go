@@ -0,0 +1,638 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// An InstanceError is a violation of a schema keyword by a value.
+type InstanceError struct {
+	// The JSON pointer of the offending value within the validated data, e.g. "/pets/0/name".
+	// It is empty for the data itself.
+	InstanceLocation string
+	// The schema keyword that failed, e.g. "minLength".
+	Keyword string
+	// Describes the violation.
+	Message string
+}
+
+func (e *InstanceError) Error() string {
+	return fmt.Sprintf("%s at %q: %s", e.Keyword, e.InstanceLocation, e.Message)
+}
+
+// InstanceErrors are all violations found when validating a value against a schema.
+type InstanceErrors []*InstanceError
+
+func (errs InstanceErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// ValidateJSON validates JSON data against the schema, following references to other schemas.
+// If the data is invalid, the error is of type InstanceErrors and contains every violation.
+// Numbers are compared exactly, see Number.
+//
+// Note that the schema itself is assumed to be valid, see Validate.
+func (s *Schema) ValidateJSON(data jsontext.Value) error {
+	v, err := unmarshalInstance(data)
+	if err != nil {
+		return err
+	}
+
+	iv := &instanceValidator{active: map[instanceKey]bool{}}
+	if errs, _ := iv.validate(s, v, "", ""); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidateValue validates a value against the schema by validating its JSON encoding, see ValidateJSON.
+// Typically, the value is the result of unmarshaling JSON into an `any`, but it may be of any type that marshals to JSON.
+func (s *Schema) ValidateValue(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return s.ValidateJSON(data)
+}
+
+// instanceKey identifies the application of a schema to a value.
+type instanceKey struct {
+	schema *Schema
+	ptr    string
+}
+
+type instanceValidator struct {
+	// the schemas that are currently applied to values, to stop on references that loop without descending into the data
+	active map[instanceKey]bool
+	// the dynamic scope, i.e. the schema resources that were entered, outermost first
+	scope []*schemaResource
+}
+
+// dynamicTarget returns the schema that the `$dynamicRef` of the schema resolves to in the current dynamic scope.
+func (iv *instanceValidator) dynamicTarget(s *Schema) *Schema {
+	target := s.dynamicRef
+
+	// only a plain name fragment that the initial target declares as `$dynamicAnchor` is resolved dynamically
+	_, name, _ := strings.Cut(s.DynamicRef, "#")
+	if name == "" || target.DynamicAnchor != name {
+		return target
+	}
+
+	for _, r := range iv.scope {
+		if a, ok := r.dynamicAnchors[name]; ok {
+			return a
+		}
+	}
+
+	return target
+}
+
+// evaluated records the properties and items of a value that were successfully evaluated by a schema and its subschemas,
+// as needed for `unevaluatedProperties` and `unevaluatedItems`.
+type evaluated struct {
+	props map[string]bool
+	items map[int]bool
+}
+
+func newEvaluated() *evaluated {
+	return &evaluated{props: map[string]bool{}, items: map[int]bool{}}
+}
+
+func (e *evaluated) merge(o *evaluated) {
+	maps.Copy(e.props, o.props)
+	maps.Copy(e.items, o.items)
+}
+
+// validateRef validates the value against the schema of the reference, if it has been resolved.
+func (iv *instanceValidator) validateRef(r *SchemaRef, v any, ptr, via string) (InstanceErrors, *evaluated) {
+	if r == nil || r.Value == nil {
+		return nil, newEvaluated()
+	}
+
+	return iv.validate(r.Value, v, ptr, via)
+}
+
+// validate validates the value at the JSON pointer against the schema.
+// The keyword via which the schema was applied is reported if the schema is `false`.
+func (iv *instanceValidator) validate(s *Schema, v any, ptr, via string) (InstanceErrors, *evaluated) {
+	ev := newEvaluated()
+
+	if s.Boolean != nil {
+		if *s.Boolean {
+			return nil, ev
+		}
+
+		if via == "" {
+			via = "false"
+		}
+
+		return InstanceErrors{{InstanceLocation: ptr, Keyword: via, Message: "no value is allowed"}}, ev
+	}
+
+	key := instanceKey{schema: s, ptr: ptr}
+	if iv.active[key] {
+		return nil, ev
+	}
+
+	iv.active[key] = true
+	defer delete(iv.active, key)
+
+	if s.resource != nil && (len(iv.scope) == 0 || iv.scope[len(iv.scope)-1] != s.resource) {
+		iv.scope = append(iv.scope, s.resource)
+		defer func() { iv.scope = iv.scope[:len(iv.scope)-1] }()
+	}
+
+	var errs InstanceErrors
+	fail := func(keyword, format string, args ...any) {
+		errs = append(errs, &InstanceError{InstanceLocation: ptr, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	// applies a subschema to the value itself, keeping its annotations if it succeeds
+	apply := func(r *SchemaRef, keyword string) bool {
+		subErrs, subEv := iv.validateRef(r, v, ptr, keyword)
+		if len(subErrs) > 0 {
+			errs = append(errs, subErrs...)
+			return false
+		}
+
+		ev.merge(subEv)
+		return true
+	}
+
+	// tests whether the value is valid against a subschema, keeping its annotations if it is
+	matches := func(r *SchemaRef) bool {
+		subErrs, subEv := iv.validateRef(r, v, ptr, "")
+		if len(subErrs) > 0 {
+			return false
+		}
+
+		ev.merge(subEv)
+		return true
+	}
+
+	if s.dynamicRef != nil {
+		apply(&SchemaRef{Value: iv.dynamicTarget(s)}, "$dynamicRef")
+	}
+
+	// Any type
+
+	if !s.Type.IsZero() && !s.Type.includesInstance(v) && !(s.Nullable && v == nil) {
+		fail("type", "expected %s, got %s", s.Type, instanceType(v))
+	}
+
+	if s.Enum != nil && !slices.ContainsFunc(s.Enum, func(e jsontext.Value) bool { return instanceEqualsJSON(v, e) }) {
+		fail("enum", "must be one of %s", enumString(s.Enum))
+	}
+
+	if s.Const != nil && !instanceEqualsJSON(v, s.Const) {
+		fail("const", "must be %s", s.Const)
+	}
+
+	if s.Format != "" {
+		if err := s.Format.check(v); err != nil {
+			fail("format", "%s", err)
+		}
+	}
+
+	// Numbers
+
+	if n, ok := v.(Number); ok {
+		exclusiveMin := s.ExclusiveMin != nil && s.ExclusiveMin.Number == nil && s.ExclusiveMin.Bool
+		exclusiveMax := s.ExclusiveMax != nil && s.ExclusiveMax.Number == nil && s.ExclusiveMax.Bool
+
+		switch {
+		case s.Min == nil:
+		case exclusiveMin && n.Cmp(*s.Min) <= 0:
+			fail("minimum", "must be > %v, got %v", *s.Min, n)
+		case n.Cmp(*s.Min) < 0:
+			fail("minimum", "must be >= %v, got %v", *s.Min, n)
+		}
+
+		switch {
+		case s.Max == nil:
+		case exclusiveMax && n.Cmp(*s.Max) >= 0:
+			fail("maximum", "must be < %v, got %v", *s.Max, n)
+		case n.Cmp(*s.Max) > 0:
+			fail("maximum", "must be <= %v, got %v", *s.Max, n)
+		}
+
+		if s.ExclusiveMin != nil && s.ExclusiveMin.Number != nil && n.Cmp(*s.ExclusiveMin.Number) <= 0 {
+			fail("exclusiveMinimum", "must be > %v, got %v", *s.ExclusiveMin.Number, n)
+		}
+
+		if s.ExclusiveMax != nil && s.ExclusiveMax.Number != nil && n.Cmp(*s.ExclusiveMax.Number) >= 0 {
+			fail("exclusiveMaximum", "must be < %v, got %v", *s.ExclusiveMax.Number, n)
+		}
+
+		if s.MultipleOf != nil && s.MultipleOf.Sign() > 0 && !n.IsMultipleOf(*s.MultipleOf) {
+			fail("multipleOf", "must be a multiple of %v, got %v", *s.MultipleOf, n)
+		}
+	}
+
+	// Strings
+
+	if str, ok := v.(string); ok {
+		length := uint(utf8.RuneCountInString(str))
+
+		if length < s.MinLength {
+			fail("minLength", "length must be >= %d, got %d", s.MinLength, length)
+		}
+
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("maxLength", "length must be <= %d, got %d", *s.MaxLength, length)
+		}
+
+		// patterns that can't be evaluated are skipped, see Document.Warnings
+		if s.Pattern != nil {
+			if ok, err := s.Pattern.Match(str); err == nil && !ok {
+				fail("pattern", "must match the regular expression %q", s.Pattern)
+			}
+		}
+	}
+
+	// Arrays
+
+	if arr, ok := v.([]any); ok {
+		n := uint(len(arr))
+
+		if n < s.MinItems {
+			fail("minItems", "must have at least %d items, got %d", s.MinItems, n)
+		}
+
+		if s.MaxItems != nil && n > *s.MaxItems {
+			fail("maxItems", "must have at most %d items, got %d", *s.MaxItems, n)
+		}
+
+		if s.UniqueItems {
+		unique:
+			for i := range arr {
+				for j := range i {
+					if instanceEqual(arr[i], arr[j]) {
+						fail("uniqueItems", "items at index %d and %d are equal", j, i)
+						break unique
+					}
+				}
+			}
+		}
+
+		for i, item := range arr {
+			itemPtr := fmt.Sprintf("%s/%d", ptr, i)
+
+			var itemErrs InstanceErrors
+			switch {
+			case i < len(s.PrefixItems):
+				itemErrs, _ = iv.validateRef(s.PrefixItems[i], item, itemPtr, "prefixItems")
+			case s.Items != nil:
+				itemErrs, _ = iv.validateRef(s.Items, item, itemPtr, "items")
+			default:
+				continue
+			}
+
+			errs = append(errs, itemErrs...)
+			ev.items[i] = true
+		}
+
+		if s.Contains != nil {
+			var count uint
+			for i, item := range arr {
+				if containsErrs, _ := iv.validateRef(s.Contains, item, fmt.Sprintf("%s/%d", ptr, i), ""); len(containsErrs) == 0 {
+					ev.items[i] = true
+					count++
+				}
+			}
+
+			minContains := uint(1)
+			if s.MinContains != nil {
+				minContains = *s.MinContains
+			}
+
+			if count < minContains {
+				keyword := "contains"
+				if s.MinContains != nil {
+					keyword = "minContains"
+				}
+
+				fail(keyword, "must contain at least %d matching items, got %d", minContains, count)
+			}
+
+			if s.MaxContains != nil && count > *s.MaxContains {
+				fail("maxContains", "must contain at most %d matching items, got %d", *s.MaxContains, count)
+			}
+		}
+	}
+
+	// Objects
+
+	if obj, ok := v.(map[string]any); ok {
+		names := slices.Sorted(maps.Keys(obj))
+		n := uint(len(obj))
+
+		if n < s.MinProperties {
+			fail("minProperties", "must have at least %d properties, got %d", s.MinProperties, n)
+		}
+
+		if s.MaxProperties != nil && n > *s.MaxProperties {
+			fail("maxProperties", "must have at most %d properties, got %d", *s.MaxProperties, n)
+		}
+
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				fail("required", "property %q is missing", name)
+			}
+		}
+
+		for name, required := range s.DependentRequired.ByIndex() {
+			if _, ok := obj[name]; !ok {
+				continue
+			}
+
+			for _, r := range required.Values {
+				if _, ok := obj[r]; !ok {
+					fail("dependentRequired", "property %q is required when %q is present", r, name)
+				}
+			}
+		}
+
+		patterns := map[string]*Pattern{}
+		for pattern := range s.PatternProperties.ByIndex() {
+			// invalid patterns are reported by Validate
+			if re, err := CompilePattern(pattern); err == nil {
+				patterns[pattern] = re
+			}
+		}
+
+		for _, name := range names {
+			propPtr := ptr + "/" + escapeJSONPointer(name)
+			matched := false
+
+			if p, ok := s.Properties[name]; ok {
+				propErrs, _ := iv.validateRef(p, obj[name], propPtr, "properties")
+				errs = append(errs, propErrs...)
+				matched = true
+			}
+
+			for pattern, p := range s.PatternProperties.ByIndex() {
+				re, ok := patterns[pattern]
+				if !ok {
+					continue
+				}
+
+				// a property that may match a pattern that can't be evaluated is not additional
+				if ok, err := re.Match(name); err != nil {
+					matched = true
+				} else if ok {
+					propErrs, _ := iv.validateRef(p, obj[name], propPtr, "patternProperties")
+					errs = append(errs, propErrs...)
+					matched = true
+				}
+			}
+
+			if !matched && s.AdditionalProperties != nil {
+				propErrs, _ := iv.validateRef(s.AdditionalProperties, obj[name], propPtr, "additionalProperties")
+				errs = append(errs, propErrs...)
+				matched = true
+			}
+
+			if matched {
+				ev.props[name] = true
+			}
+
+			if s.PropertyNames != nil {
+				if nameErrs, _ := iv.validateRef(s.PropertyNames, name, ptr, ""); len(nameErrs) > 0 {
+					fail("propertyNames", "property name %q is invalid", name)
+				}
+			}
+		}
+
+		for name, d := range s.DependentSchemas.ByIndex() {
+			if _, ok := obj[name]; ok {
+				apply(d, "dependentSchemas")
+			}
+		}
+	}
+
+	// Composition
+
+	for _, sub := range s.AllOf {
+		apply(sub, "allOf")
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			// all subschemas are evaluated to collect their annotations
+			matched = matches(sub) || matched
+		}
+
+		if !matched {
+			fail("anyOf", "must match at least one schema")
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		var valid []int
+		for i, sub := range s.OneOf {
+			if matches(sub) {
+				valid = append(valid, i)
+			}
+		}
+
+		switch len(valid) {
+		case 0:
+			fail("oneOf", "must match exactly one schema, matches none")
+		case 1:
+		default:
+			fail("oneOf", "must match exactly one schema, matches %v", valid)
+		}
+	}
+
+	if s.Not != nil {
+		if notErrs, _ := iv.validateRef(s.Not, v, ptr, ""); len(notErrs) == 0 {
+			fail("not", "must not match the schema")
+		}
+	}
+
+	if s.If != nil {
+		if matches(s.If) {
+			if s.Then != nil {
+				apply(s.Then, "then")
+			}
+		} else if s.Else != nil {
+			apply(s.Else, "else")
+		}
+	}
+
+	// Unevaluated items and properties, considering all other keywords
+
+	if arr, ok := v.([]any); ok && s.UnevaluatedItems != nil {
+		for i, item := range arr {
+			if ev.items[i] {
+				continue
+			}
+
+			itemErrs, _ := iv.validateRef(s.UnevaluatedItems, item, fmt.Sprintf("%s/%d", ptr, i), "unevaluatedItems")
+			errs = append(errs, itemErrs...)
+			ev.items[i] = true
+		}
+	}
+
+	if obj, ok := v.(map[string]any); ok && s.UnevaluatedProperties != nil {
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			if ev.props[name] {
+				continue
+			}
+
+			propErrs, _ := iv.validateRef(s.UnevaluatedProperties, obj[name], ptr+"/"+escapeJSONPointer(name), "unevaluatedProperties")
+			errs = append(errs, propErrs...)
+			ev.props[name] = true
+		}
+	}
+
+	return errs, ev
+}
+
+// instanceType returns the data type of a value, where numbers without fraction are integers.
+func instanceType(v any) DataType {
+	switch val := v.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case Number:
+		if val.IsInteger() {
+			return TypeInteger
+		}
+
+		return TypeNumber
+	case string:
+		return TypeString
+	case []any:
+		return TypeArray
+	default:
+		return TypeObject
+	}
+}
+
+// includesInstance reports whether the value is of one of the data types, where integers are also numbers.
+func (t SchemaType) includesInstance(v any) bool {
+	it := instanceType(v)
+	return t.Includes(it) || (it == TypeInteger && t.Includes(TypeNumber))
+}
+
+// instanceEqualsJSON reports whether a value equals the JSON value, e.g. of `enum` or `const`.
+func instanceEqualsJSON(v any, data jsontext.Value) bool {
+	other, err := unmarshalInstance(data)
+	return err == nil && instanceEqual(v, other)
+}
+
+// jsonEqual reports whether two JSON values are equal, regardless of their formatting, see instanceEqual.
+func jsonEqual(a, b jsontext.Value) bool {
+	v, err := unmarshalInstance(a)
+	return err == nil && instanceEqualsJSON(v, b)
+}
+
+// instanceEqual reports whether two values are equal, where numbers are equal if their values are.
+func instanceEqual(a, b any) bool {
+	switch a := a.(type) {
+	case Number:
+		b, ok := b.(Number)
+		return ok && a.Cmp(b) == 0
+	case []any:
+		b, ok := b.([]any)
+		return ok && slices.EqualFunc(a, b, instanceEqual)
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		return ok && maps.EqualFunc(a, b, instanceEqual)
+	default:
+		return a == b
+	}
+}
+
+// unmarshalInstance unmarshals JSON data like unmarshaling into an `any`,
+// except that numbers are of type Number, so that no precision is lost.
+func unmarshalInstance(data jsontext.Value) (any, error) {
+	dec := jsontext.NewDecoder(bytes.NewReader(data))
+
+	v, err := decodeInstance(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.ReadToken(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after top-level value")
+	}
+
+	return v, nil
+}
+
+func decodeInstance(dec *jsontext.Decoder) (any, error) {
+	switch dec.PeekKind() {
+	case jsontext.KindBeginObject:
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+
+		obj := map[string]any{}
+		for dec.PeekKind() != jsontext.KindEndObject {
+			tok, err := dec.ReadToken()
+			if err != nil {
+				return nil, err
+			}
+
+			name := tok.String() // the token is only valid until the next read
+			if obj[name], err = decodeInstance(dec); err != nil {
+				return nil, err
+			}
+		}
+
+		_, err := dec.ReadToken()
+		return obj, err
+	case jsontext.KindBeginArray:
+		if _, err := dec.ReadToken(); err != nil {
+			return nil, err
+		}
+
+		arr := []any{}
+		for dec.PeekKind() != jsontext.KindEndArray {
+			v, err := decodeInstance(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, v)
+		}
+
+		_, err := dec.ReadToken()
+		return arr, err
+	default:
+		tok, err := dec.ReadToken()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok.Kind() {
+		case jsontext.KindNull:
+			return nil, nil
+		case jsontext.KindTrue, jsontext.KindFalse:
+			return tok.Bool(), nil
+		case jsontext.KindNumber:
+			return Number(tok.String()), nil
+		default:
+			return tok.String(), nil
+		}
+	}
+}
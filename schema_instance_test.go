@@ -0,0 +1,270 @@
+package openapi_test
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+const petStoreSchemas = `{
+"openapi": "3.2.0",
+"info": {"title": "Pets", "version": "1.0"},
+"components": {"schemas": {
+	"Pet": {
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "format": "int64", "minimum": 1},
+			"name": {"type": "string", "minLength": 1, "maxLength": 10, "pattern": "^[A-Z]"},
+			"tag": {"type": ["string", "null"], "enum": ["cat", "dog", null]},
+			"born": {"type": "string", "format": "date"},
+			"weight": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.5},
+			"owner": {"$ref": "#/components/schemas/Owner"},
+			"friends": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}, "uniqueItems": true, "maxItems": 2}
+		},
+		"required": ["id", "name"],
+		"additionalProperties": false
+	},
+	"Owner": {
+		"type": "object",
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"phone": {"type": "string"}
+		},
+		"anyOf": [
+			{"type": "object", "properties": {"email": {"type": "string"}}, "required": ["email"]},
+			{"type": "object", "properties": {"phone": {"type": "string"}}, "required": ["phone"]}
+		],
+		"dependentRequired": {"phone": ["email"]}
+	}
+}}
+}`
+
+func TestSchema_ValidateJSON(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(petStoreSchemas))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	pet := doc.Components.Schemas["Pet"]
+
+	for _, tc := range []struct {
+		data string
+		errs []openapi.InstanceError
+	}{
+		{`{"id": 1, "name": "Tom"}`, nil},
+		{`{"id": 1, "name": "Tom", "tag": null, "born": "2020-02-29", "weight": 4.5}`, nil},
+		{`{"id": 1, "name": "Tom", "owner": {"email": "jane@example.com"}, "friends": [{"id": 2, "name": "Rex", "tag": "dog"}]}`, nil},
+		{`[]`, []openapi.InstanceError{
+			{InstanceLocation: "", Keyword: "type", Message: "expected object, got array"},
+		}},
+		{`{"name": ""}`, []openapi.InstanceError{
+			{InstanceLocation: "", Keyword: "required", Message: `property "id" is missing`},
+			{InstanceLocation: "/name", Keyword: "minLength", Message: "length must be >= 1, got 0"},
+			{InstanceLocation: "/name", Keyword: "pattern", Message: `must match the regular expression "^[A-Z]"`},
+		}},
+		{`{"id": 0.5, "name": "Tom", "color": "black"}`, []openapi.InstanceError{
+			{InstanceLocation: "/color", Keyword: "additionalProperties", Message: "no value is allowed"},
+			{InstanceLocation: "/id", Keyword: "type", Message: "expected integer, got number"},
+			{InstanceLocation: "/id", Keyword: "format", Message: "0.5 is not an integer"},
+			{InstanceLocation: "/id", Keyword: "minimum", Message: "must be >= 1, got 0.5"},
+		}},
+		{`{"id": 1, "name": "Tom", "tag": "bird", "born": "2021-02-29", "weight": 0.2}`, []openapi.InstanceError{
+			{InstanceLocation: "/born", Keyword: "format", Message: `"2021-02-29" is not a valid date`},
+			{InstanceLocation: "/tag", Keyword: "enum", Message: `must be one of ["cat" "dog" null]`},
+			{InstanceLocation: "/weight", Keyword: "multipleOf", Message: "must be a multiple of 0.5, got 0.2"},
+		}},
+		{`{"id": 1, "name": "Tom", "weight": 0}`, []openapi.InstanceError{
+			{InstanceLocation: "/weight", Keyword: "exclusiveMinimum", Message: "must be > 0, got 0"},
+		}},
+		{`{"id": 1, "name": "Tom", "owner": {}}`, []openapi.InstanceError{
+			{InstanceLocation: "/owner", Keyword: "anyOf", Message: "must match at least one schema"},
+		}},
+		{`{"id": 1, "name": "Tom", "owner": {"phone": "123", "email": "jane"}}`, []openapi.InstanceError{
+			{InstanceLocation: "/owner/email", Keyword: "format", Message: `"jane" is not a valid email`},
+		}},
+		{`{"id": 1, "name": "Tom", "owner": {"phone": "123"}}`, []openapi.InstanceError{
+			{InstanceLocation: "/owner", Keyword: "dependentRequired", Message: `property "email" is required when "phone" is present`},
+		}},
+		{`{"id": 1, "name": "Tom", "friends": [{"id": 2, "name": "Rex"}, {"id": 2, "name": "Rex"}, {"id": 3}]}`, []openapi.InstanceError{
+			{InstanceLocation: "/friends", Keyword: "maxItems", Message: "must have at most 2 items, got 3"},
+			{InstanceLocation: "/friends", Keyword: "uniqueItems", Message: "items at index 0 and 1 are equal"},
+			{InstanceLocation: "/friends/2", Keyword: "required", Message: `property "name" is missing`},
+		}},
+	} {
+		t.Run(tc.data, func(t *testing.T) {
+			err := pet.ValidateJSON(jsontext.Value(tc.data))
+			if tc.errs == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			var errs openapi.InstanceErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("want: %T, got: %T", errs, err)
+			}
+
+			if len(errs) != len(tc.errs) {
+				t.Fatalf("want %d errors, got:\n%v", len(tc.errs), errs)
+			}
+
+			for i, want := range tc.errs {
+				if *errs[i] != want {
+					t.Fatalf("want %+v, got %+v", want, *errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateJSON_Keywords(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name   string
+		schema string
+		data   string
+		err    string
+	}{
+		{"false", `false`, `1`, `false at "": no value is allowed`},
+		{"true", `true`, `1`, ``},
+		{"const", `{"const": {"a": [1, 2]}}`, `{"a": [1, 2.0]}`, ``},
+		{"const mismatch", `{"const": "a"}`, `"b"`, `const at "": must be "a"`},
+		{"nullable", `{"type": "string", "nullable": true}`, `null`, ``},
+		{"not", `{"not": {"type": "string"}}`, `"a"`, `not at "": must not match the schema`},
+		{"tiny integer", `{"type": "integer"}`, `1e-2000`, `type at "": expected integer, got number`},
+		{"tiny multipleOf", `{"multipleOf": 1}`, `-1e-2000`, `multipleOf at "": must be a multiple of 1, got -1e-2000`},
+		{"oneOf none", `{"oneOf": [{"type": "string"}, {"type": "boolean"}]}`, `1`, `oneOf at "": must match exactly one schema, matches none`},
+		{"oneOf several", `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, `1`, `oneOf at "": must match exactly one schema, matches [0 1]`},
+		{"allOf", `{"allOf": [{"minLength": 2}, {"maxLength": 3}]}`, `"abcd"`, `maxLength at "": length must be <= 3, got 4`},
+		{"if then", `{"if": {"type": "string"}, "then": {"minLength": 2}, "else": {"minimum": 2}}`, `"a"`, `minLength at "": length must be >= 2, got 1`},
+		{"if else", `{"if": {"type": "string"}, "then": {"minLength": 2}, "else": {"minimum": 2}}`, `1`, `minimum at "": must be >= 2, got 1`},
+		{"exclusive maximum 3.0", `{"maximum": 2, "exclusiveMaximum": true}`, `2`, `maximum at "": must be < 2, got 2`},
+		{"prefixItems", `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`, `["a", 1, "b"]`, `type at "/2": expected integer, got string`},
+		{"contains", `{"contains": {"type": "string"}}`, `[1, 2]`, `contains at "": must contain at least 1 matching items, got 0`},
+		{"maxContains", `{"contains": {"type": "string"}, "maxContains": 1}`, `["a", "b"]`, `maxContains at "": must contain at most 1 matching items, got 2`},
+		{"unevaluatedItems", `{"prefixItems": [{}], "contains": {"type": "string"}, "unevaluatedItems": false}`, `[1, "a", 2]`, `unevaluatedItems at "/2": no value is allowed`},
+		{"patternProperties", `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "integer"}}`, `{"x-a": "b", "c": "d"}`, `type at "/c": expected integer, got string`},
+		{"propertyNames", `{"propertyNames": {"maxLength": 2}}`, `{"abc": 1}`, `propertyNames at "": property name "abc" is invalid`},
+		{"min and max properties", `{"minProperties": 2, "maxProperties": 0}`, `{"a": 1}`, "minProperties at \"\": must have at least 2 properties, got 1\nmaxProperties at \"\": must have at most 0 properties, got 1"},
+		{"dependentSchemas", `{"dependentSchemas": {"a": {"required": ["b"]}}}`, `{"a": 1}`, `required at "": property "b" is missing`},
+		{"unevaluatedProperties", `{"allOf": [{"properties": {"a": {}}}], "unevaluatedProperties": false}`, `{"a": 1, "b/c": 2}`, `unevaluatedProperties at "/b~1c": no value is allowed`},
+		{"unevaluatedProperties after failed anyOf", `{"anyOf": [{"properties": {"a": {}}, "required": ["b"]}, true], "unevaluatedProperties": false}`, `{"a": 1}`, `unevaluatedProperties at "/a": no value is allowed`},
+		{"int32", `{"format": "int32"}`, `2147483648`, `format at "": 2147483648 is out of range for int32`},
+		{"int64 max", `{"format": "int64", "maximum": 9223372036854775807}`, `9223372036854775807`, ``},
+		{"int64 overflow", `{"format": "int64"}`, `9223372036854775808`, `format at "": 9223372036854775808 is out of range for int64`},
+		{"exact maximum", `{"maximum": 9007199254740992}`, `9007199254740993`, `maximum at "": must be <= 9007199254740992, got 9007199254740993`},
+		{"exact exclusiveMaximum", `{"exclusiveMaximum": 0.3}`, `0.3`, `exclusiveMaximum at "": must be < 0.3, got 0.3`},
+		{"exact multipleOf", `{"multipleOf": 0.01}`, `19.99`, ``},
+		{"exact enum", `{"enum": [9007199254740993]}`, `9007199254740992`, `enum at "": must be one of [9007199254740993]`},
+		{"equal numbers", `{"enum": [1e2]}`, `100.0`, ``},
+		{"unique numbers", `{"uniqueItems": true}`, `[1, 1.0]`, `uniqueItems at "": items at index 0 and 1 are equal`},
+		{"uuid", `{"format": "uuid"}`, `"123e4567-e89b-12d3-a456-426614174000"`, ``},
+		{"duration", `{"format": "duration"}`, `"P1DT"`, `format at "": "P1DT" is not a valid duration`},
+		{"ipv4", `{"format": "ipv4"}`, `"::1"`, `format at "": "::1" is not a valid ipv4`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &openapi.Schema{}
+			if err := json.Unmarshal([]byte(tc.schema), s); err != nil {
+				t.Fatal(err)
+			}
+
+			err := s.ValidateJSON(jsontext.Value(tc.data))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else if err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSchema_ValidateValue(t *testing.T) {
+	t.Parallel()
+
+	s := &openapi.Schema{
+		Type:     openapi.Types(openapi.TypeObject),
+		Required: []string{"name"},
+	}
+
+	if err := s.ValidateValue(struct {
+		Name string `json:"name"`
+	}{"Tom"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ValidateValue(map[string]any{}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := s.ValidateValue(func() {}); err == nil || errors.As(err, new(openapi.InstanceErrors)) {
+		t.Fatalf("expected marshaling error, got %v", err)
+	}
+}
+
+func TestSchema_ValidateJSON_DynamicRef(t *testing.T) {
+	t.Parallel()
+
+	// a strict tree extends a tree by overriding its dynamic anchor, so that its nodes are strict as well
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"info": {"title": "Trees", "version": "1.0"},
+"components": {"schemas": {
+	"Tree": {
+		"$id": "https://example.com/tree",
+		"$dynamicAnchor": "node",
+		"type": "object",
+		"properties": {
+			"data": true,
+			"children": {"type": "array", "items": {"$dynamicRef": "#node"}}
+		}
+	},
+	"StrictTree": {
+		"$id": "https://example.com/strict-tree",
+		"$dynamicAnchor": "node",
+		"type": "object",
+		"allOf": [{"$ref": "https://example.com/tree"}],
+		"unevaluatedProperties": false
+	}
+}}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := jsontext.Value(`{"children": [{"daat": 1}]}`)
+	if err := doc.Components.Schemas["Tree"].ValidateJSON(data); err != nil {
+		t.Fatal(err)
+	}
+
+	err = doc.Components.Schemas["StrictTree"].ValidateJSON(data)
+
+	var errs openapi.InstanceErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("want: %T, got: %v", errs, err)
+	}
+
+	// the misspelled property of the child is not evaluated by the strict tree,
+	// so that the tree fails and its properties are not evaluated either
+	if got, want := errs.Error(), `unevaluatedProperties at "/children/0/daat": no value is allowed
+unevaluatedProperties at "/children": no value is allowed`; got != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
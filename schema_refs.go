@@ -50,6 +50,12 @@ func (ss *SchemaRefs) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 	return ordmap.UnmarshalJSONFrom(ss, dec, setIndexRef[Schema, *Schema])
 }
 
+func (l *loader) collectSchemaRefs(ss SchemaRefs, ref ref) {
+	for name, s := range ss.ByIndex() {
+		l.collectSchemaRef(s, append(ref, escapeJSONPointer(name)))
+	}
+}
+
 func (l *loader) resolveSchemaRefs(ss SchemaRefs) error {
 	for name, value := range ss.ByIndex() {
 		if err := l.resolveSchemaRef(value); err != nil {
@@ -26,29 +26,270 @@ func TestSchema_JSON(t *testing.T) {
 		],
 		"default": "side"
 	}`), &openapi.Schema{})
+
+	// OpenAPI 3.0
+	testJSON(t, []byte(`{
+		"type": "number",
+		"minimum": 0,
+		"maximum": 100,
+		"exclusiveMinimum": true,
+		"exclusiveMaximum": false,
+		"multipleOf": 0.5
+	}`), &openapi.Schema{})
+
+	// OpenAPI 3.1
+	testJSON(t, []byte(`{
+		"type": "integer",
+		"exclusiveMinimum": 0,
+		"exclusiveMaximum": 10
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"type": "string",
+		"minLength": 1,
+		"maxLength": 64
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"credit_card": {"type": "string"}
+		},
+		"patternProperties": {
+			"^x-": {"type": "string"},
+			"^[0-9]+$": {"type": "integer"}
+		},
+		"required": ["name", "x-id"],
+		"propertyNames": {"type": "string", "maxLength": 32},
+		"minProperties": 1,
+		"maxProperties": 10,
+		"dependentRequired": {
+			"credit_card": ["billing_address", "name"],
+			"billing_address": ["credit_card"]
+		},
+		"dependentSchemas": {
+			"credit_card": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}},
+				"required": ["name"]
+			}
+		},
+		"unevaluatedProperties": {"type": "boolean"}
+	}`), &openapi.Schema{})
+
+	// a tuple of latitude and longitude
+	testJSON(t, []byte(`{
+		"type": "array",
+		"maxItems": 2,
+		"prefixItems": [
+			{"type": "number", "minimum": -90, "maximum": 90},
+			{"type": "number", "minimum": -180, "maximum": 180}
+		]
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"type": "array",
+		"items": {"type": "string"},
+		"contains": {"type": "string", "pattern": "^admin$"},
+		"minContains": 1,
+		"maxContains": 1,
+		"uniqueItems": true,
+		"unevaluatedItems": {"type": "string"}
+	}`), &openapi.Schema{})
+
+	// method-specific fields
+	testJSON(t, []byte(`{
+		"type": "object",
+		"if": {
+			"type": "object",
+			"properties": {"method": {"const": "card"}}
+		},
+		"then": {
+			"type": "object",
+			"properties": {"card_number": {"type": "string"}},
+			"required": ["card_number"]
+		},
+		"else": {
+			"type": "object",
+			"properties": {"iban": {"type": "string"}},
+			"required": ["iban"]
+		},
+		"properties": {
+			"method": {"type": "string", "enum": ["card", "sepa"]}
+		}
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{"const": null}`), &openapi.Schema{})
+
+	// nullable types
+	testJSON(t, []byte(`{
+		"type": ["string", "null"],
+		"format": "date-time",
+		"default": null
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"type": ["integer"],
+		"minimum": 1
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"type": "array",
+		"items": {
+			"type": "string",
+			"xml": {"name": "animal"}
+		},
+		"xml": {"name": "aliens", "wrapped": true}
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"type": "string",
+		"format": "password",
+		"examples": ["correct horse battery staple", "hunter2"],
+		"writeOnly": true,
+		"deprecated": true,
+		"externalDocs": {
+			"description": "Password policy",
+			"url": "https://example.com/docs/passwords"
+		}
+	}`), &openapi.Schema{})
+
+	testJSON(t, []byte(`{
+		"$id": "https://example.com/schemas/tree",
+		"$dynamicAnchor": "node",
+		"$comment": "a tree whose nodes can be extended",
+		"$defs": {
+			"Label": {"$anchor": "Label", "type": "string"}
+		},
+		"type": "object",
+		"properties": {
+			"label": {"type": "string"},
+			"children": {
+				"type": "array",
+				"items": {"$dynamicRef": "#node"}
+			}
+		}
+	}`), &openapi.Schema{})
+
+	// boolean schemas
+	testJSON(t, []byte(`true`), &openapi.Schema{})
+	testJSON(t, []byte(`false`), &openapi.Schema{})
+	testJSON(t, []byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"metadata": true,
+			"legacy": false
+		},
+		"additionalProperties": false
+	}`), &openapi.Schema{})
+	testJSON(t, []byte(`{
+		"type": "array",
+		"prefixItems": [{"type": "string"}, {"type": "integer"}],
+		"items": false
+	}`), &openapi.Schema{})
+
+	// OpenAPI 3.0
+	testJSON(t, []byte(`{
+		"type": "string",
+		"nullable": true,
+		"default": null
+	}`), &openapi.Schema{})
+}
+
+func TestSchema_IsNullable(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		s    openapi.Schema
+		want bool
+	}{
+		{openapi.Schema{Type: openapi.Types(openapi.TypeString)}, false},
+		{openapi.Schema{Type: openapi.Types(openapi.TypeString, openapi.TypeNull)}, true},
+		{openapi.Schema{Type: openapi.Types(openapi.TypeString), Nullable: true}, true},
+	} {
+		if got := tc.s.IsNullable(); got != tc.want {
+			t.Fatalf("%s: got: %t, want: %t", tc.s.Type, got, tc.want)
+		}
+	}
 }
 
 func TestSchema_Validate(t *testing.T) {
 	t.Parallel()
 
-	str := &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.TypeString}}
-	num := &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.TypeNumber}}
+	str := &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}}
+	num := &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeNumber)}}
 
 	for i, tc := range []openapi.Schema{
-		{Type: openapi.TypeNumber, Default: jsontext.Value("3.14")},
-		{Type: openapi.TypeInteger, Default: jsontext.Value("3")},
-		{Type: openapi.TypeInteger, Format: openapi.FormatDuration, Default: jsontext.Value("3")}, // e.g. seconds
-		{Type: openapi.TypeString, Format: openapi.FormatByte},                                    // base64-encoded data
+		{Type: openapi.Types(openapi.TypeNumber), Default: jsontext.Value("3.14")},
+		{Type: openapi.Types(openapi.TypeInteger), Default: jsontext.Value("3")},
+		{Type: openapi.Types(openapi.TypeInteger), Format: openapi.FormatDuration, Default: jsontext.Value("3")}, // e.g. seconds
+		{Type: openapi.Types(openapi.TypeString), Format: openapi.FormatByte},                                    // base64-encoded data
+		{Type: openapi.Types(openapi.TypeString), Format: "foo"},                                                 // unknown formats are tolerated
+		{Type: openapi.Types(openapi.TypeNumber), Min: new(openapi.Number("0")), ExclusiveMin: &openapi.ExclusiveBound{Bool: true}, MultipleOf: new(openapi.Number("0.01"))},
+		{Type: openapi.Types(openapi.TypeInteger), ExclusiveMax: &openapi.ExclusiveBound{Number: new(openapi.Number("100"))}},
+		{Type: openapi.Types(openapi.TypeString), MinLength: 1, MaxLength: new(uint(1))},
+		// prefixItems describe every item
+		{Type: openapi.Types(openapi.TypeArray), PrefixItems: openapi.SchemaRefList{str, num}, MaxItems: new(uint(2))},
+		{Type: openapi.Types(openapi.TypeArray), PrefixItems: openapi.SchemaRefList{str, num}, Items: num},
 		// oneOf, anyOf, not allow type to be omitted
 		// See: https://spec.openapis.org/oas/v3.2.0.html#schema-object
 		{OneOf: openapi.SchemaRefList{str, num}},
 		{AnyOf: openapi.SchemaRefList{str, num}},
 		{Not: str},
 		// combining with a type is also valid
-		{Type: openapi.TypeString, OneOf: openapi.SchemaRefList{str}},
+		{Type: openapi.Types(openapi.TypeString), OneOf: openapi.SchemaRefList{str}},
 		// enum accepts any JSON type per JSON Schema 2020-12
-		{Type: openapi.TypeInteger, Enum: []jsontext.Value{jsontext.Value("4"), jsontext.Value("6"), jsontext.Value("8")}},
-		{Type: openapi.TypeString, Enum: []jsontext.Value{jsontext.Value(`"foo"`), jsontext.Value(`"bar"`)}},
+		{Type: openapi.Types(openapi.TypeInteger), Enum: []jsontext.Value{jsontext.Value("4"), jsontext.Value("6"), jsontext.Value("8")}},
+		{Type: openapi.Types(openapi.TypeString), Enum: []jsontext.Value{jsontext.Value(`"foo"`), jsontext.Value(`"bar"`)}},
+		// const determines the type
+		{Const: jsontext.Value(`"card"`)},
+		{Type: openapi.Types(openapi.TypeString), Const: jsontext.Value(`"card"`), Enum: []jsontext.Value{jsontext.Value(`"card"`)}},
+		{Type: openapi.Types(openapi.TypeNumber), Const: jsontext.Value(`1.0`), Enum: []jsontext.Value{jsontext.Value(`1`)}},
+		{Type: openapi.Types(openapi.TypeObject), Const: jsontext.Value(`{"a": 1, "b": 2}`), Enum: []jsontext.Value{jsontext.Value(`{"b":2,"a":1}`)}},
+		{Type: openapi.Types(openapi.TypeNumber), Default: jsontext.Value(`1.0`), Enum: []jsontext.Value{jsontext.Value(`1`)}},
+		{Type: openapi.Types(openapi.TypeObject), Default: jsontext.Value(`{"a": 1, "b": 2}`), Enum: []jsontext.Value{jsontext.Value(`{"b":2,"a":1}`)}},
+		{If: str, Then: str, Else: num},
+		{If: str, Then: str},
+		// the checks follow the set of types
+		{Type: openapi.Types(openapi.TypeInteger, openapi.TypeNull), Format: openapi.FormatInt64, Min: new(openapi.Number("1"))},
+		{Type: openapi.Types(openapi.TypeInteger, openapi.TypeNumber), Min: new(openapi.Number("0.5"))},
+		{Type: openapi.Types(openapi.TypeArray, openapi.TypeNull), Items: str, Default: jsontext.Value("null")},
+		{Type: openapi.Types(openapi.TypeString), Nullable: true, Enum: []jsontext.Value{jsontext.Value(`"foo"`), jsontext.Value("null")}},
+		// boolean schemas have no other fields
+		{Boolean: new(true)},
+		{Boolean: new(false)},
+		{
+			Type:  openapi.Types(openapi.TypeArray),
+			Items: &openapi.SchemaRef{Value: &openapi.Schema{Boolean: new(true)}},
+		},
+		{
+			Type:                 openapi.Types(openapi.TypeObject),
+			AdditionalProperties: &openapi.SchemaRef{Value: &openapi.Schema{Boolean: new(false)}},
+		},
+		// the discriminating property is defined for all alternatives
+		{
+			Type:       openapi.Types(openapi.TypeObject),
+			Properties: openapi.SchemaRefs{"kind": str},
+			Required:   []string{"kind"},
+			OneOf: openapi.SchemaRefList{{
+				Ref:   &openapi.Reference{Identifier: "#/components/schemas/Circle"},
+				Value: &openapi.Schema{Type: openapi.Types(openapi.TypeObject)},
+			}},
+			Discriminator: &openapi.Discriminator{
+				PropertyName: "kind",
+				Mapping:      openapi.MapOfStrings{"circle": {Value: "Circle"}},
+			},
+		},
+		// an optional discriminating property needs a default mapping
+		{
+			OneOf: openapi.SchemaRefList{{Value: &openapi.Schema{
+				Type:       openapi.Types(openapi.TypeObject),
+				Properties: openapi.SchemaRefs{"kind": str},
+			}}},
+			Discriminator: &openapi.Discriminator{PropertyName: "kind", DefaultMapping: "Circle"},
+		},
 	} {
 		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
 			if err := tc.Validate(); err != nil {
@@ -67,89 +308,214 @@ func TestSchema_Validate_Error(t *testing.T) {
 	}{
 		{openapi.Schema{}, "type is required"},
 		{openapi.Schema{
-			Type: "foo",
+			ID:   "https://example.com/schemas/pet#foo",
+			Type: openapi.Types(openapi.TypeObject),
+		}, `$id ("https://example.com/schemas/pet#foo") is invalid: must not contain a non-empty fragment`},
+		{openapi.Schema{
+			Anchor: "1pet",
+			Type:   openapi.Types(openapi.TypeObject),
+		}, `$anchor ("1pet") is invalid: must match the regular expression "^[A-Za-z_][-A-Za-z0-9._]*$"`},
+		{openapi.Schema{
+			DynamicAnchor: "my node",
+			Type:          openapi.Types(openapi.TypeObject),
+		}, `$dynamicAnchor ("my node") is invalid: must match the regular expression "^[A-Za-z_][-A-Za-z0-9._]*$"`},
+		{openapi.Schema{
+			DynamicRef: "%",
+		}, `$dynamicRef: parse "%": invalid URL escape "%"`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeObject),
+			Defs: openapi.SchemaRefs{"Name": {Value: &openapi.Schema{}}},
+		}, `$defs["Name"].type is required`},
+		{openapi.Schema{
+			Defs:       openapi.SchemaRefs{"Name": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}}},
+			Deprecated: true,
+		}, `type is required`},
+		{openapi.Schema{
+			Type: openapi.Types("foo"),
 		}, `type ("foo") is invalid, must be one of: "integer", "number", "string", "array", "boolean", "object", "null"`},
 		{openapi.Schema{
-			Type: openapi.TypeArray,
+			Type: openapi.Types(openapi.TypeArray),
 		}, `items is required`},
 		{openapi.Schema{
-			Type:   openapi.TypeString,
-			Format: "foo",
-		}, `format ("foo") is invalid, must be one of: ` + validFormats},
+			Type:   openapi.Types(openapi.TypeBoolean),
+			Format: openapi.FormatDecimal,
+		}, `format ("decimal") is invalid: only valid for number or string type, got boolean`},
 		{openapi.Schema{
-			Type:   openapi.TypeString,
+			Type:   openapi.Types(openapi.TypeString),
 			Format: openapi.FormatInt64,
 		}, `format ("int64") is invalid: only valid for integer type, got string`},
 		{openapi.Schema{
-			Type:   openapi.TypeString,
+			Type:   openapi.Types(openapi.TypeString),
 			Format: openapi.FormatDouble,
 		}, `format ("double") is invalid: only valid for number type, got string`},
 		{openapi.Schema{
-			Type:   openapi.TypeBoolean,
+			Type:   openapi.Types(openapi.TypeBoolean),
 			Format: openapi.FormatByte,
 		}, `format ("byte") is invalid: only valid for string type, got boolean`},
 		{openapi.Schema{
-			Type:   openapi.TypeBoolean,
+			Type:   openapi.Types(openapi.TypeBoolean),
 			Format: openapi.FormatPassword,
 		}, `format ("password") is invalid: only valid for string type, got boolean`},
 		{openapi.Schema{
-			Type:   openapi.TypeBoolean,
+			Type:   openapi.Types(openapi.TypeBoolean),
 			Format: openapi.FormatDuration,
 		}, `format ("duration") is invalid: only valid for integer or string type, got boolean`},
 		{openapi.Schema{
-			Type:  openapi.TypeBoolean,
+			Type:  openapi.Types(openapi.TypeBoolean),
 			Items: &openapi.SchemaRef{},
 		}, `items is invalid: only valid for array type, got boolean`},
 		{openapi.Schema{
-			Type: openapi.TypeArray,
+			Type: openapi.Types(openapi.TypeArray),
 			Items: &openapi.SchemaRef{
 				Value: &openapi.Schema{
-					Type: openapi.TypeNumber,
-					Min:  new(4.0),
-					Max:  new(3.0),
+					Type: openapi.Types(openapi.TypeNumber),
+					Min:  new(openapi.Number("4")),
+					Max:  new(openapi.Number("3")),
 				},
 			},
 		}, `items.minimum (4) is invalid: minimum is greater than maximum (4 > 3)`},
 		{openapi.Schema{
-			Type: openapi.TypeBoolean,
-			Min:  new(3.0),
+			Type: openapi.Types(openapi.TypeBoolean),
+			Min:  new(openapi.Number("3")),
 		}, `minimum (3) is invalid: only valid for number type, got boolean`},
 		{openapi.Schema{
-			Type: openapi.TypeBoolean,
-			Max:  new(4.0),
+			Type: openapi.Types(openapi.TypeBoolean),
+			Max:  new(openapi.Number("4")),
 		}, `maximum (4) is invalid: only valid for number type, got boolean`},
 		{openapi.Schema{
-			Type: openapi.TypeInteger,
-			Min:  new(5.3),
+			Type: openapi.Types(openapi.TypeInteger),
+			Min:  new(openapi.Number("5.3")),
 		}, `minimum (5.3) is invalid: not an integer`},
 		{openapi.Schema{
-			Type: openapi.TypeInteger,
-			Max:  new(4.2),
+			Type: openapi.Types(openapi.TypeInteger),
+			Max:  new(openapi.Number("4.2")),
 		}, `maximum (4.2) is invalid: not an integer`},
 		{openapi.Schema{
-			Type: openapi.TypeInteger,
-			Min:  new(5.0),
-			Max:  new(4.0),
+			Type: openapi.Types(openapi.TypeInteger),
+			Min:  new(openapi.Number("5")),
+			Max:  new(openapi.Number("4")),
 		}, `minimum (5) is invalid: minimum is greater than maximum (5 > 4)`},
 		{openapi.Schema{
-			Type: openapi.TypeNumber,
-			Min:  new(5.6),
-			Max:  new(4.2),
+			Type: openapi.Types(openapi.TypeNumber),
+			Min:  new(openapi.Number("5.6")),
+			Max:  new(openapi.Number("4.2")),
 		}, `minimum (5.6) is invalid: minimum is greater than maximum (5.6 > 4.2)`},
 		{openapi.Schema{
-			Type:     openapi.TypeNumber,
+			Type:         openapi.Types(openapi.TypeNumber),
+			ExclusiveMin: &openapi.ExclusiveBound{Bool: true},
+		}, `exclusiveMinimum (true) is invalid: minimum is required`},
+		{openapi.Schema{
+			Type:         openapi.Types(openapi.TypeNumber),
+			ExclusiveMax: &openapi.ExclusiveBound{Bool: true},
+		}, `exclusiveMaximum (true) is invalid: maximum is required`},
+		{openapi.Schema{
+			Type:         openapi.Types(openapi.TypeString),
+			ExclusiveMin: &openapi.ExclusiveBound{Number: new(openapi.Number("3"))},
+		}, `exclusiveMinimum is invalid: only valid for number type, got string`},
+		{openapi.Schema{
+			Type:         openapi.Types(openapi.TypeString),
+			ExclusiveMax: &openapi.ExclusiveBound{Number: new(openapi.Number("3"))},
+		}, `exclusiveMaximum is invalid: only valid for number type, got string`},
+		{openapi.Schema{
+			Type:       openapi.Types(openapi.TypeNumber),
+			MultipleOf: new(openapi.Number("0")),
+		}, `multipleOf (0) is invalid: must be greater than 0`},
+		{openapi.Schema{
+			Type:       openapi.Types(openapi.TypeInteger),
+			MultipleOf: new(openapi.Number("-2")),
+		}, `multipleOf (-2) is invalid: must be greater than 0`},
+		{openapi.Schema{
+			Type:       openapi.Types(openapi.TypeString),
+			MultipleOf: new(openapi.Number("2")),
+		}, `multipleOf (2) is invalid: only valid for number type, got string`},
+		{openapi.Schema{
+			Type:      openapi.Types(openapi.TypeString),
+			MinLength: 5,
+			MaxLength: new(uint(4)),
+		}, `minLength (5) is invalid: minLength is greater than maxLength (5 > 4)`},
+		{openapi.Schema{
+			Type:      openapi.Types(openapi.TypeNumber),
+			MinLength: 3,
+		}, `minLength (3) is invalid: only valid for string type, got number`},
+		{openapi.Schema{
+			Type:      openapi.Types(openapi.TypeNumber),
+			MaxLength: new(uint(4)),
+		}, `maxLength (4) is invalid: only valid for string type, got number`},
+		{openapi.Schema{
+			Type:     openapi.Types(openapi.TypeNumber),
 			MinItems: 3,
 		}, `minItems (3) is invalid: only valid for array type, got number`},
 		{openapi.Schema{
-			Type:     openapi.TypeNumber,
+			Type:     openapi.Types(openapi.TypeNumber),
 			MaxItems: new(uint(4)),
 		}, `maxItems (4) is invalid: only valid for array type, got number`},
 		{openapi.Schema{
-			Type:     openapi.TypeArray,
+			Type:     openapi.Types(openapi.TypeArray),
 			MinItems: 5,
 			MaxItems: new(uint(4)),
 			Items:    &openapi.SchemaRef{},
 		}, `minItems (5) is invalid: minItems is greater than maxItems (5 > 4)`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeArray),
+			PrefixItems: openapi.SchemaRefList{{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeNumber)}}},
+			MaxItems:    new(uint(2)),
+		}, `items is required`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeArray),
+			PrefixItems: openapi.SchemaRefList{{Value: &openapi.Schema{}}},
+			MaxItems:    new(uint(1)),
+		}, `prefixItems[0].type is required`},
+		{openapi.Schema{
+			Type:     openapi.Types(openapi.TypeArray),
+			Items:    &openapi.SchemaRef{},
+			Contains: &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `contains.type is required`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeArray),
+			Items:       &openapi.SchemaRef{},
+			Contains:    &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+			MinContains: new(uint(3)),
+			MaxContains: new(uint(2)),
+		}, `minContains (3) is invalid: minContains is greater than maxContains (3 > 2)`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeArray),
+			Items:       &openapi.SchemaRef{},
+			MinContains: new(uint(3)),
+		}, `minContains (3) is invalid: property has no effect when contains is not present`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeArray),
+			Items:       &openapi.SchemaRef{},
+			MaxContains: new(uint(2)),
+		}, `maxContains (2) is invalid: property has no effect when contains is not present`},
+		{openapi.Schema{
+			Type:             openapi.Types(openapi.TypeArray),
+			Items:            &openapi.SchemaRef{},
+			UnevaluatedItems: &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `unevaluatedItems.type is required`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeObject),
+			PrefixItems: openapi.SchemaRefList{},
+		}, `prefixItems is invalid: only valid for array type, got object`},
+		{openapi.Schema{
+			Type:     openapi.Types(openapi.TypeObject),
+			Contains: &openapi.SchemaRef{},
+		}, `contains is invalid: only valid for array type, got object`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeObject),
+			MinContains: new(uint(1)),
+		}, `minContains (1) is invalid: only valid for array type, got object`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeObject),
+			MaxContains: new(uint(1)),
+		}, `maxContains (1) is invalid: only valid for array type, got object`},
+		{openapi.Schema{
+			Type:        openapi.Types(openapi.TypeObject),
+			UniqueItems: true,
+		}, `uniqueItems (true) is invalid: only valid for array type, got object`},
+		{openapi.Schema{
+			Type:             openapi.Types(openapi.TypeObject),
+			UnevaluatedItems: &openapi.SchemaRef{},
+		}, `unevaluatedItems is invalid: only valid for array type, got object`},
 		{openapi.Schema{
 			AllOf: openapi.SchemaRefList{
 				{Value: &openapi.Schema{}},
@@ -169,58 +535,239 @@ func TestSchema_Validate_Error(t *testing.T) {
 			Not: &openapi.SchemaRef{Value: &openapi.Schema{}},
 		}, `not.type is required`},
 		{openapi.Schema{
-			Type: openapi.TypeObject,
+			Type: openapi.Types(openapi.TypeObject),
 			Properties: openapi.SchemaRefs{
 				"foo": &openapi.SchemaRef{Value: &openapi.Schema{}},
 			},
 		}, `properties["foo"].type is required`},
 		{openapi.Schema{
-			Type:     openapi.TypeObject,
+			Type:     openapi.Types(openapi.TypeObject),
 			Required: []string{"foo"},
 		}, `required[0] ("foo") is invalid: property does not exist`},
 		{openapi.Schema{
-			Type: openapi.TypeObject,
+			Type: openapi.Types(openapi.TypeObject),
 			AdditionalProperties: &openapi.SchemaRef{
 				Value: &openapi.Schema{},
 			},
 		}, `additionalProperties.type is required`},
 		{openapi.Schema{
-			Type:       openapi.TypeBoolean,
+			Type: openapi.Types(openapi.TypeObject),
+			PatternProperties: openapi.SchemaRefs{
+				"^x-": &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+			},
+			Required: []string{"x-id", "id"},
+		}, `required[1] ("id") is invalid: property does not exist`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeObject),
+			PatternProperties: openapi.SchemaRefs{
+				"^(x-": &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+			},
+		}, "patternProperties[\"^(x-\"] (\"^(x-\") is invalid: error parsing regexp: missing closing ): `^(x-`"},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeObject),
+			PatternProperties: openapi.SchemaRefs{
+				"^x-": &openapi.SchemaRef{Value: &openapi.Schema{}},
+			},
+		}, `patternProperties["^x-"].type is required`},
+		{openapi.Schema{
+			Type:          openapi.Types(openapi.TypeObject),
+			PropertyNames: &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `propertyNames.type is required`},
+		{openapi.Schema{
+			Type:          openapi.Types(openapi.TypeObject),
+			PropertyNames: &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeInteger)}},
+		}, `propertyNames.type ("integer") is invalid: property names are strings`},
+		{openapi.Schema{
+			Type:          openapi.Types(openapi.TypeObject),
+			MinProperties: 3,
+			MaxProperties: new(uint(2)),
+		}, `minProperties (3) is invalid: minProperties is greater than maxProperties (3 > 2)`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeObject),
+			DependentRequired: openapi.MapOfStringLists{
+				"credit_card": {Values: []string{"name", "billing_address", "name"}},
+			},
+		}, `dependentRequired["credit_card"][2] ("name") is invalid: must be unique`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeObject),
+			DependentSchemas: openapi.SchemaRefs{
+				"credit_card": &openapi.SchemaRef{Value: &openapi.Schema{}},
+			},
+		}, `dependentSchemas["credit_card"].type is required`},
+		{openapi.Schema{
+			Type:                  openapi.Types(openapi.TypeObject),
+			UnevaluatedProperties: &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `unevaluatedProperties.type is required`},
+		{openapi.Schema{
+			Type:       openapi.Types(openapi.TypeBoolean),
 			Properties: openapi.SchemaRefs{},
 		}, `properties is invalid: only valid for object type, got boolean`},
 		{openapi.Schema{
-			Type: openapi.TypeBoolean,
+			Type:              openapi.Types(openapi.TypeBoolean),
+			PatternProperties: openapi.SchemaRefs{},
+		}, `patternProperties is invalid: only valid for object type, got boolean`},
+		{openapi.Schema{
+			Type:          openapi.Types(openapi.TypeBoolean),
+			PropertyNames: &openapi.SchemaRef{},
+		}, `propertyNames is invalid: only valid for object type, got boolean`},
+		{openapi.Schema{
+			Type:          openapi.Types(openapi.TypeArray),
+			Items:         &openapi.SchemaRef{},
+			MinProperties: 1,
+		}, `minProperties (1) is invalid: only valid for object type, got array`},
+		{openapi.Schema{
+			Type:          openapi.Types(openapi.TypeString),
+			MaxProperties: new(uint(3)),
+		}, `maxProperties (3) is invalid: only valid for object type, got string`},
+		{openapi.Schema{
+			Type:              openapi.Types(openapi.TypeString),
+			DependentRequired: openapi.MapOfStringLists{},
+		}, `dependentRequired is invalid: only valid for object type, got string`},
+		{openapi.Schema{
+			Type:             openapi.Types(openapi.TypeString),
+			DependentSchemas: openapi.SchemaRefs{},
+		}, `dependentSchemas is invalid: only valid for object type, got string`},
+		{openapi.Schema{
+			Type:                  openapi.Types(openapi.TypeString),
+			UnevaluatedProperties: &openapi.SchemaRef{},
+		}, `unevaluatedProperties is invalid: only valid for object type, got string`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeBoolean),
 			AdditionalProperties: &openapi.SchemaRef{
 				Value: &openapi.Schema{},
 			},
 		}, `additionalProperties is invalid: only valid for object type, got boolean`},
 		{openapi.Schema{
-			Type: openapi.TypeBoolean,
+			Type: openapi.Types(openapi.TypeBoolean),
 			Enum: []jsontext.Value{jsontext.Value(`"not-a-bool"`)},
 		}, `enum[0] ("not-a-bool") is invalid: must be a boolean value`},
 		{openapi.Schema{
-			Type: openapi.TypeInteger,
+			Type: openapi.Types(openapi.TypeInteger),
 			Enum: []jsontext.Value{jsontext.Value("3.14")},
 		}, `enum[0] (3.14) is invalid: must be a integer value`},
 		{openapi.Schema{
-			Type:    openapi.TypeBoolean,
+			Type: openapi.SchemaType{Types: []openapi.DataType{openapi.TypeString, "foo"}, Array: true},
+		}, `type[1] ("foo") is invalid, must be one of: "integer", "number", "string", "array", "boolean", "object", "null"`},
+		{openapi.Schema{
+			Nullable: true,
+			Const:    jsontext.Value(`"card"`),
+		}, `nullable (true) is invalid: property has no effect when type is not present`},
+		{openapi.Schema{
+			Type:    openapi.Types(openapi.TypeString, openapi.TypeNull),
+			Default: jsontext.Value("3"),
+		}, `default (3) is invalid: does not match schema type, got [string null]`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeInteger, openapi.TypeNull),
+			Min:  new(openapi.Number("0.5")),
+		}, `minimum (0.5) is invalid: not an integer`},
+		{openapi.Schema{
+			Type:   openapi.Types(openapi.TypeBoolean, openapi.TypeNull),
+			Format: openapi.FormatEmail,
+		}, `format ("email") is invalid: only valid for string type, got [boolean null]`},
+		{openapi.Schema{
+			OneOf:         openapi.SchemaRefList{{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeObject)}}},
+			Discriminator: &openapi.Discriminator{},
+		}, `discriminator.propertyName is required`},
+		{openapi.Schema{
+			OneOf: openapi.SchemaRefList{
+				{Value: &openapi.Schema{
+					Type:       openapi.Types(openapi.TypeObject),
+					Properties: openapi.SchemaRefs{"kind": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}}},
+					Required:   []string{"kind"},
+				}},
+				{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeObject)}},
+			},
+			Discriminator: &openapi.Discriminator{PropertyName: "kind"},
+		}, `oneOf[1] is invalid: discriminator property "kind" does not exist`},
+		{openapi.Schema{
+			AnyOf: openapi.SchemaRefList{{Value: &openapi.Schema{
+				Type:       openapi.Types(openapi.TypeObject),
+				Properties: openapi.SchemaRefs{"kind": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}}},
+			}}},
+			Discriminator: &openapi.Discriminator{PropertyName: "kind"},
+		}, `anyOf[0] is invalid: discriminator property "kind" is not required`},
+		{openapi.Schema{
+			Type:       openapi.Types(openapi.TypeObject),
+			Properties: openapi.SchemaRefs{"kind": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}}},
+			Required:   []string{"kind"},
+			OneOf: openapi.SchemaRefList{{
+				Ref:   &openapi.Reference{Identifier: "#/components/schemas/Circle"},
+				Value: &openapi.Schema{Type: openapi.Types(openapi.TypeObject)},
+			}},
+			Discriminator: &openapi.Discriminator{
+				PropertyName: "kind",
+				Mapping:      openapi.MapOfStrings{"square": {Value: "#/components/schemas/Square"}},
+			},
+		}, `discriminator.mapping["square"] ("#/components/schemas/Square") is invalid: not one of the schemas in oneOf or anyOf`},
+		{openapi.Schema{
+			Type:      openapi.Types(openapi.TypeString),
+			ReadOnly:  true,
+			WriteOnly: true,
+		}, `readOnly and writeOnly are mutually exclusive`},
+		{openapi.Schema{
+			Type:         openapi.Types(openapi.TypeString),
+			ExternalDocs: &openapi.ExternalDocs{},
+		}, `externalDocs.url is required`},
+		{openapi.Schema{
+			Type:  openapi.Types(openapi.TypeArray),
+			Items: &openapi.SchemaRef{Value: &openapi.Schema{Deprecated: true}},
+		}, `items.type is required`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeString),
+			XML:  &openapi.XML{Namespace: "sample"},
+		}, `xml.namespace ("sample") is invalid: must be an absolute URI`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeString),
+			XML:  &openapi.XML{Wrapped: true},
+		}, `xml.wrapped (true) is invalid: only valid for array type, got string`},
+		{openapi.Schema{
+			Type:  openapi.Types(openapi.TypeBoolean),
+			Const: jsontext.Value(`"yes"`),
+		}, `const ("yes") is invalid: must be a boolean value`},
+		{openapi.Schema{
+			Type:  openapi.Types(openapi.TypeString),
+			Const: jsontext.Value(`"foo"`),
+			Enum:  []jsontext.Value{jsontext.Value(`"bar"`), jsontext.Value(`"buz"`)},
+		}, `const ("foo") is invalid: is not one of the enums (["bar" "buz"])`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeString),
+			If:   &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `if.type is required`},
+		{openapi.Schema{
+			If:   &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+			Then: &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `then.type is required`},
+		{openapi.Schema{
+			If:   &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+			Else: &openapi.SchemaRef{Value: &openapi.Schema{}},
+		}, `else.type is required`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeString),
+			Then: &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+		}, `then is invalid: property has no effect when if is not present`},
+		{openapi.Schema{
+			Type: openapi.Types(openapi.TypeString),
+			Else: &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeString)}},
+		}, `else is invalid: property has no effect when if is not present`},
+		{openapi.Schema{
+			Type:    openapi.Types(openapi.TypeBoolean),
 			Default: jsontext.Value(`"foo"`),
 		}, `default ("foo") is invalid: does not match schema type, got boolean`},
 		{openapi.Schema{
-			Type:    openapi.TypeString,
+			Type:    openapi.Types(openapi.TypeString),
 			Default: jsontext.Value(`"foo"`),
 			Enum:    []jsontext.Value{jsontext.Value(`"bar"`), jsontext.Value(`"buz"`)},
 		}, `default ("foo") is invalid: is not one of the enums (["bar" "buz"])`},
 		{openapi.Schema{
-			Type:    openapi.TypeInteger,
+			Type:    openapi.Types(openapi.TypeInteger),
 			Default: jsontext.Value("3.14"),
 		}, `default (3.14) is invalid: does not match schema type, got integer`},
 		{openapi.Schema{
-			Type:    openapi.TypeString,
+			Type:    openapi.Types(openapi.TypeString),
 			Default: jsontext.Value("3.14"),
 		}, `default (3.14) is invalid: does not match schema type, got string`},
 		{openapi.Schema{
-			Type:    openapi.TypeString,
+			Type:    openapi.Types(openapi.TypeString),
 			Default: jsontext.Value("3"),
 		}, `default (3) is invalid: does not match schema type, got string`},
 	} {
@@ -243,7 +790,7 @@ func TestSchema_UnmarshalNumericEnum(t *testing.T) {
 		t.Fatalf("unmarshal failed: %v", err)
 	}
 
-	if s.Type != openapi.TypeInteger {
+	if !s.Type.Includes(openapi.TypeInteger) {
 		t.Errorf("Type = %q, want integer", s.Type)
 	}
 
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"slices"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+// SchemaType is the value of the `type` keyword of a Schema Object.
+//
+// It is either a single data type, e.g. `"string"`, or an array of unique data types, e.g. `["string", "null"]`.
+// Since OpenAPI 3.1, the latter is the standard way to express that a value can be null.
+type SchemaType struct {
+	// The data types the value can have.
+	Types []DataType
+	// Whether the keyword is an array, even if it holds a single data type.
+	Array bool
+}
+
+// Types returns the schema type with the given data types.
+// A single data type is marshaled as string, several as array.
+func Types(ds ...DataType) SchemaType { return SchemaType{Types: ds} }
+
+// Includes reports whether the value can have the given data type.
+func (t SchemaType) Includes(d DataType) bool { return slices.Contains(t.Types, d) }
+
+// IsZero reports whether the keyword is absent.
+func (t SchemaType) IsZero() bool { return len(t.Types) == 0 && !t.Array }
+
+// String returns the single data type, or the data types in brackets, e.g. `[string null]`.
+func (t SchemaType) String() string {
+	switch {
+	case t.IsZero():
+		return ""
+	case len(t.Types) == 1:
+		return string(t.Types[0])
+	default:
+		return fmt.Sprint(t.Types)
+	}
+}
+
+func (t SchemaType) Validate() error {
+	if !t.Array && len(t.Types) == 1 {
+		return t.Types[0].Validate()
+	}
+
+	for i, d := range t.Types {
+		if err := d.Validate(); err != nil {
+			return &errpath.ErrIndex{Index: i, Err: err}
+		}
+
+		if slices.Contains(t.Types[:i], d) {
+			return &errpath.ErrIndex{Index: i, Err: &errpath.ErrInvalid[DataType]{
+				Value:   d,
+				Message: "must be unique",
+			}}
+		}
+	}
+
+	return nil
+}
+
+// includesKindOf reports whether the kind of a JSON value is compatible with one of the data types.
+// Without any data type, every value is compatible.
+func (t SchemaType) includesKindOf(v jsontext.Value) bool {
+	if t.IsZero() {
+		return true
+	}
+
+	return slices.ContainsFunc(t.Types, func(d DataType) bool {
+		return enumKindMatchesType(v, d)
+	})
+}
+
+var _ json.MarshalerTo = (*SchemaType)(nil)
+
+// MarshalJSONTo marshals the type in its original shape, i.e. as string or array.
+func (t *SchemaType) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !t.Array && len(t.Types) == 1 {
+		return enc.WriteToken(jsontext.String(string(t.Types[0])))
+	}
+
+	return json.MarshalEncode(enc, t.Types)
+}
+
+var _ json.UnmarshalerFrom = (*SchemaType)(nil)
+
+// UnmarshalJSONFrom unmarshals the type from either a string or an array of strings.
+func (t *SchemaType) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	switch kind := dec.PeekKind(); kind {
+	case '"':
+		*t = SchemaType{Types: make([]DataType, 1)}
+		return json.UnmarshalDecode(dec, &t.Types[0])
+	case '[':
+		*t = SchemaType{Array: true}
+		return json.UnmarshalDecode(dec, &t.Types)
+	default:
+		return fmt.Errorf("expected string or array, got %s", kind)
+	}
+}
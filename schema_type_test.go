@@ -0,0 +1,94 @@
+package openapi_test
+
+import (
+	"encoding/json/v2"
+	"slices"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestSchemaType_JSON(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		in   string
+		want openapi.SchemaType
+	}{
+		{`"string"`, openapi.Types(openapi.TypeString)},
+		{`["string"]`, openapi.SchemaType{Types: []openapi.DataType{openapi.TypeString}, Array: true}},
+		{`["string","null"]`, openapi.SchemaType{Types: []openapi.DataType{openapi.TypeString, openapi.TypeNull}, Array: true}},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			typ := &openapi.SchemaType{}
+			if err := json.Unmarshal([]byte(tc.in), typ); err != nil {
+				t.Fatal(err)
+			}
+
+			if typ.Array != tc.want.Array || !slices.Equal(typ.Types, tc.want.Types) {
+				t.Fatalf("got: %+v, want: %+v", typ, tc.want)
+			}
+
+			out, err := json.Marshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(out) != tc.in {
+				t.Fatalf("got: %s, want: %s", out, tc.in)
+			}
+		})
+	}
+}
+
+func TestSchemaType_JSON_Error(t *testing.T) {
+	t.Parallel()
+
+	err := json.Unmarshal([]byte(`3`), &openapi.SchemaType{})
+	semErr := errAs[json.SemanticError](t, err)
+	if want := "expected string or array, got number"; semErr.Err.Error() != want {
+		t.Fatalf("got: %v, want: %v", semErr.Err, want)
+	}
+}
+
+func TestSchemaType(t *testing.T) {
+	t.Parallel()
+
+	typ := openapi.Types(openapi.TypeString, openapi.TypeNull)
+	if !typ.Includes(openapi.TypeNull) {
+		t.Fatal("expected type to include null")
+	}
+
+	if typ.Includes(openapi.TypeNumber) {
+		t.Fatal("expected type not to include number")
+	}
+
+	if want := "[string null]"; typ.String() != want {
+		t.Fatalf("got: %s, want: %s", typ, want)
+	}
+
+	// a single data type is printed as is, even if given as an array
+	typ = openapi.SchemaType{Types: []openapi.DataType{openapi.TypeString}, Array: true}
+	if want := "string"; typ.String() != want {
+		t.Fatalf("got: %s, want: %s", typ, want)
+	}
+}
+
+func TestSchemaType_Validate_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		typ openapi.SchemaType
+		err string
+	}{
+		{openapi.Types("foo"), `a value ("foo") is invalid, must be one of: "integer", "number", "string", "array", "boolean", "object", "null"`},
+		{openapi.Types(openapi.TypeString, "foo"), `[1] ("foo") is invalid, must be one of: "integer", "number", "string", "array", "boolean", "object", "null"`},
+		{openapi.Types(openapi.TypeString, openapi.TypeNull, openapi.TypeString), `[2] ("string") is invalid: must be unique`},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			if err := tc.typ.Validate(); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %s", tc.err, err)
+			}
+		})
+	}
+}
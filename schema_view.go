@@ -0,0 +1,127 @@
+package openapi
+
+import "slices"
+
+// RequestView returns the schema as it applies to requests:
+// properties that are read only are omitted, and no longer required, in the schema and all of its subschemas.
+//
+// The schema itself is not modified. Subschemas that are referenced several times, including recursively, are copied once.
+func (s *Schema) RequestView() *Schema {
+	return s.view(func(p *Schema) bool { return p.ReadOnly }, map[*Schema]*Schema{})
+}
+
+// ResponseView returns the schema as it applies to responses:
+// properties that are write only are omitted, and no longer required, in the schema and all of its subschemas.
+//
+// The schema itself is not modified. Subschemas that are referenced several times, including recursively, are copied once.
+func (s *Schema) ResponseView() *Schema {
+	return s.view(func(p *Schema) bool { return p.WriteOnly }, map[*Schema]*Schema{})
+}
+
+// view returns a copy of the schema without the properties to omit.
+// The views map each schema that was already copied to its copy.
+func (s *Schema) view(omit func(*Schema) bool, views map[*Schema]*Schema) *Schema {
+	if v, ok := views[s]; ok {
+		return v
+	}
+
+	v := &Schema{}
+	*v = *s
+	views[s] = v
+
+	omitted := func(name string) bool {
+		p, ok := s.Properties[name]
+		return ok && p.Value != nil && omit(p.Value)
+	}
+
+	if s.Properties != nil {
+		v.Properties = make(SchemaRefs, len(s.Properties))
+		for name, p := range s.Properties {
+			if !omitted(name) {
+				v.Properties[name] = viewSchemaRef(p, omit, views)
+			}
+		}
+	}
+
+	if s.Required != nil {
+		v.Required = slices.DeleteFunc(slices.Clone(s.Required), omitted)
+	}
+
+	v.Defs = viewSchemaRefs(s.Defs, omit, views)
+	v.AllOf = viewSchemaRefList(s.AllOf, omit, views)
+	v.OneOf = viewSchemaRefList(s.OneOf, omit, views)
+	v.AnyOf = viewSchemaRefList(s.AnyOf, omit, views)
+	v.Not = viewSchemaRef(s.Not, omit, views)
+	v.If = viewSchemaRef(s.If, omit, views)
+	v.Then = viewSchemaRef(s.Then, omit, views)
+	v.Else = viewSchemaRef(s.Else, omit, views)
+	v.PrefixItems = viewSchemaRefList(s.PrefixItems, omit, views)
+	v.Items = viewSchemaRef(s.Items, omit, views)
+	v.Contains = viewSchemaRef(s.Contains, omit, views)
+	v.UnevaluatedItems = viewSchemaRef(s.UnevaluatedItems, omit, views)
+	v.PatternProperties = viewSchemaRefs(s.PatternProperties, omit, views)
+	v.AdditionalProperties = viewSchemaRef(s.AdditionalProperties, omit, views)
+	v.PropertyNames = viewSchemaRef(s.PropertyNames, omit, views)
+	v.DependentSchemas = viewSchemaRefs(s.DependentSchemas, omit, views)
+	v.UnevaluatedProperties = viewSchemaRef(s.UnevaluatedProperties, omit, views)
+
+	if s.dynamicRef != nil {
+		v.dynamicRef = s.dynamicRef.view(omit, views)
+	}
+
+	if d := s.Discriminator; d != nil {
+		v.Discriminator = &Discriminator{}
+		*v.Discriminator = *d
+
+		if d.mapping != nil {
+			v.Discriminator.mapping = make(map[string]*Schema, len(d.mapping))
+			for value, target := range d.mapping {
+				v.Discriminator.mapping[value] = target.view(omit, views)
+			}
+		}
+
+		if d.defaultMapping != nil {
+			v.Discriminator.defaultMapping = d.defaultMapping.view(omit, views)
+		}
+	}
+
+	return v
+}
+
+func viewSchemaRef(r *SchemaRef, omit func(*Schema) bool, views map[*Schema]*Schema) *SchemaRef {
+	if r == nil || r.Value == nil {
+		return r
+	}
+
+	v := &SchemaRef{}
+	*v = *r
+	v.Value = r.Value.view(omit, views)
+
+	return v
+}
+
+func viewSchemaRefList(ss SchemaRefList, omit func(*Schema) bool, views map[*Schema]*Schema) SchemaRefList {
+	if ss == nil {
+		return nil
+	}
+
+	v := make(SchemaRefList, len(ss))
+	for i, s := range ss {
+		v[i] = viewSchemaRef(s, omit, views)
+	}
+
+	return v
+}
+
+func viewSchemaRefs(ss SchemaRefs, omit func(*Schema) bool, views map[*Schema]*Schema) SchemaRefs {
+	if ss == nil {
+		return nil
+	}
+
+	v := make(SchemaRefs, len(ss))
+	for name, s := range ss {
+		v[name] = viewSchemaRef(s, omit, views)
+	}
+
+	return v
+}
@@ -0,0 +1,69 @@
+package openapi_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestSchema_View(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"info": {"title": "Users", "version": "1.0"},
+"components": {"schemas": {
+	"User": {
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "readOnly": true},
+			"name": {"type": "string"},
+			"password": {"type": "string", "writeOnly": true},
+			"friends": {"type": "array", "items": {"$ref": "#/components/schemas/User"}}
+		},
+		"required": ["id", "name", "password"]
+	}
+}}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := doc.Components.Schemas["User"]
+
+	for _, tc := range []struct {
+		name       string
+		view       *openapi.Schema
+		properties []string
+		required   []string
+	}{
+		{"request", user.RequestView(), []string{"name", "password", "friends"}, []string{"name", "password"}},
+		{"response", user.ResponseView(), []string{"id", "name", "friends"}, []string{"id", "name"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var properties []string
+			for name := range tc.view.Properties.ByIndex() {
+				properties = append(properties, name)
+			}
+
+			if !slices.Equal(properties, tc.properties) {
+				t.Fatalf("properties: want %v, got %v", tc.properties, properties)
+			}
+
+			if !slices.Equal(tc.view.Required, tc.required) {
+				t.Fatalf("required: want %v, got %v", tc.required, tc.view.Required)
+			}
+
+			// the recursive reference points to the view as well
+			if got := tc.view.Properties["friends"].Value.Items.Value; got != tc.view {
+				t.Fatal("expected friends to be a list of the view")
+			}
+		})
+	}
+
+	// the original schema is unchanged
+	if len(user.Properties) != 4 || len(user.Required) != 3 {
+		t.Fatalf("schema was modified: %v, %v", user.Properties, user.Required)
+	}
+}
@@ -0,0 +1,421 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// EncodeXML encodes a value to XML following the XML Objects of the schema and its subschemas.
+// The value is expected in the form produced by unmarshaling JSON into an `any`,
+// i.e. objects are `map[string]any` and arrays are `[]any`.
+//
+// The name is used for the root element, unless the XML Object of the schema replaces it.
+// Typically, this is the name of the schema component.
+func (s *Schema) EncodeXML(name string, v any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	e := &xmlEncoder{buf: buf, enc: xml.NewEncoder(buf)}
+
+	// the root is always an element
+	if err := e.element(s, name, v); err != nil {
+		return nil, err
+	}
+
+	if err := e.enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeXML decodes XML to a value following the XML Objects of the schema and its subschemas.
+// The value has the form produced by unmarshaling JSON into an `any`,
+// i.e. objects are `map[string]any` and arrays are `[]any`, except that numbers are of type Number.
+func (s *Schema) DecodeXML(data []byte) (any, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decodeXML(root, root.name.Local)
+}
+
+// xmlNodeType returns the kind of XML node the schema produces, considering the defaults and the deprecated fields.
+func (s *Schema) xmlNodeType() XMLNodeType {
+	isArray := s.Type.Includes(TypeArray)
+
+	if x := s.XML; x != nil {
+		switch {
+		case x.NodeType != "":
+			return x.NodeType
+		case x.Attribute:
+			return XMLNodeTypeAttribute
+		case isArray && x.Wrapped:
+			return XMLNodeTypeElement
+		}
+	}
+
+	if isArray {
+		return XMLNodeTypeNone
+	}
+
+	return XMLNodeTypeElement
+}
+
+// xmlLocalName returns the name of the element or attribute without prefix.
+func (s *Schema) xmlLocalName(name string) string {
+	if s.XML != nil && s.XML.Name != "" {
+		return s.XML.Name
+	}
+
+	return name
+}
+
+// xmlName returns the name of the element or attribute, including the prefix if there is one.
+func (s *Schema) xmlName(name string) xml.Name {
+	local := s.xmlLocalName(name)
+	if s.XML != nil && s.XML.Prefix != "" {
+		local = s.XML.Prefix + ":" + local
+	}
+
+	return xml.Name{Local: local}
+}
+
+// xmlNamespace returns the attribute declaring the namespace of the schema, if any.
+func (s *Schema) xmlNamespace() (xml.Attr, bool) {
+	if s.XML == nil || s.XML.Namespace == "" {
+		return xml.Attr{}, false
+	}
+
+	name := "xmlns"
+	if s.XML.Prefix != "" {
+		name += ":" + s.XML.Prefix
+	}
+
+	return xml.Attr{Name: xml.Name{Local: name}, Value: s.XML.Namespace}, true
+}
+
+// schemaOrEmpty returns the schema of the reference or, if there is none, the empty schema.
+func schemaOrEmpty(r *SchemaRef) *Schema {
+	if r == nil || r.Value == nil {
+		return &Schema{}
+	}
+
+	return r.Value
+}
+
+type xmlEncoder struct {
+	buf *bytes.Buffer
+	enc *xml.Encoder
+}
+
+// node writes the value as the kind of node the schema produces.
+func (e *xmlEncoder) node(s *Schema, name string, v any) error {
+	switch s.xmlNodeType() {
+	case XMLNodeTypeNone:
+		return e.content(s, name, v)
+	case XMLNodeTypeText:
+		return e.enc.EncodeToken(xml.CharData(xmlText(v)))
+	case XMLNodeTypeCDATA:
+		if err := e.enc.Flush(); err != nil {
+			return err
+		}
+
+		// "]]>" ends the section, so it is split across two sections
+		text := strings.ReplaceAll(xmlText(v), "]]>", "]]]]><![CDATA[>")
+		_, err := fmt.Fprintf(e.buf, "<![CDATA[%s]]>", text)
+		return err
+	default:
+		return e.element(s, name, v)
+	}
+}
+
+// element writes the value as an element, with the properties of an object that are attributes as attributes.
+func (e *xmlEncoder) element(s *Schema, name string, v any) error {
+	start := xml.StartElement{Name: s.xmlName(name)}
+	if ns, ok := s.xmlNamespace(); ok {
+		start.Attr = append(start.Attr, ns)
+	}
+
+	if obj, ok := v.(map[string]any); ok {
+		for propName, prop := range s.Properties.ByIndex() {
+			val, ok := obj[propName]
+			ps := schemaOrEmpty(prop)
+			if !ok || ps.xmlNodeType() != XMLNodeTypeAttribute {
+				continue
+			}
+
+			if ns, ok := ps.xmlNamespace(); ok {
+				start.Attr = append(start.Attr, ns)
+			}
+
+			start.Attr = append(start.Attr, xml.Attr{Name: ps.xmlName(propName), Value: xmlText(val)})
+		}
+	}
+
+	if err := e.enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := e.content(s, name, v); err != nil {
+		return err
+	}
+
+	return e.enc.EncodeToken(start.End())
+}
+
+// content writes the content of the element that represents the value.
+// The name is the one inferred for the value, which is also the default name of array items.
+func (e *xmlEncoder) content(s *Schema, name string, v any) error {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		for propName, prop := range s.Properties.ByIndex() {
+			pv, ok := val[propName]
+			ps := schemaOrEmpty(prop)
+			if !ok || ps.xmlNodeType() == XMLNodeTypeAttribute {
+				continue
+			}
+
+			if err := e.node(ps, propName, pv); err != nil {
+				return err
+			}
+		}
+
+		// any other properties follow in alphabetical order
+		additional := schemaOrEmpty(s.AdditionalProperties)
+		for _, key := range slices.Sorted(maps.Keys(val)) {
+			if _, ok := s.Properties[key]; ok {
+				continue
+			}
+
+			if err := e.node(additional, key, val[key]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case []any:
+		items := schemaOrEmpty(s.Items)
+		for _, item := range val {
+			if err := e.node(items, name, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return e.enc.EncodeToken(xml.CharData(xmlText(v)))
+	}
+}
+
+// xmlText formats a primitive value as text.
+func xmlText(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case Number:
+		return val.String()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// xmlNode is an element of a parsed XML document.
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     string
+}
+
+// parseXML parses an XML document into its root element.
+func parseXML(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name, attrs: t.Attr}
+			if len(stack) == 0 {
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+
+	return root, nil
+}
+
+// decodeXML decodes the element following the schema.
+// The name is the one inferred for the value, which is also the default name of array items.
+func (s *Schema) decodeXML(n *xmlNode, name string) (any, error) {
+	switch {
+	case s.Type.Includes(TypeArray):
+		items := schemaOrEmpty(s.Items)
+
+		arr := make([]any, 0, len(n.children))
+		for _, child := range n.children {
+			v, err := items.decodeXML(child, name)
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, v)
+		}
+
+		return arr, nil
+	case s.Type.Includes(TypeObject) || s.Properties != nil ||
+		(s.Type.IsZero() && len(n.children) > 0):
+		return s.decodeXMLObject(n)
+	default:
+		return s.decodeXMLText(n.text)
+	}
+}
+
+// decodeXMLObject decodes the attributes, text and child elements of the element into the properties of an object.
+func (s *Schema) decodeXMLObject(n *xmlNode) (map[string]any, error) {
+	obj := map[string]any{}
+	used := make([]bool, len(n.children))
+
+	for propName, prop := range s.Properties.ByIndex() {
+		ps := schemaOrEmpty(prop)
+		local := ps.xmlLocalName(propName)
+
+		switch ps.xmlNodeType() {
+		case XMLNodeTypeAttribute:
+			for _, attr := range n.attrs {
+				if attr.Name.Local != local || attr.Name.Space == "xmlns" {
+					continue
+				}
+
+				v, err := ps.decodeXMLText(attr.Value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", propName, err)
+				}
+
+				obj[propName] = v
+			}
+		case XMLNodeTypeText, XMLNodeTypeCDATA:
+			v, err := ps.decodeXMLText(strings.TrimSpace(n.text))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", propName, err)
+			}
+
+			obj[propName] = v
+		case XMLNodeTypeNone:
+			// the items of an unwrapped array are child elements of their own
+			items := schemaOrEmpty(ps.Items)
+			itemName := items.xmlLocalName(propName)
+
+			var arr []any
+			for i, child := range n.children {
+				if used[i] || child.name.Local != itemName {
+					continue
+				}
+
+				v, err := items.decodeXML(child, propName)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", propName, err)
+				}
+
+				arr, used[i] = append(arr, v), true
+			}
+
+			if arr != nil {
+				obj[propName] = arr
+			}
+		default:
+			for i, child := range n.children {
+				if used[i] || child.name.Local != local {
+					continue
+				}
+
+				v, err := ps.decodeXML(child, propName)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", propName, err)
+				}
+
+				obj[propName], used[i] = v, true
+				break
+			}
+		}
+	}
+
+	// any other child elements are additional properties
+	additional := schemaOrEmpty(s.AdditionalProperties)
+	for i, child := range n.children {
+		if used[i] {
+			continue
+		}
+
+		v, err := additional.decodeXML(child, child.name.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", child.name.Local, err)
+		}
+
+		obj[child.name.Local] = v
+	}
+
+	return obj, nil
+}
+
+// decodeXMLText decodes the text of an element or attribute according to the schema type.
+func (s *Schema) decodeXMLText(text string) (any, error) {
+	if text == "" && s.IsNullable() {
+		return nil, nil
+	}
+
+	if s.Type.Includes(TypeNumber) || s.Type.Includes(TypeInteger) {
+		if v := jsontext.Value(text); v.Kind() == jsontext.KindNumber && v.IsValid() {
+			return Number(text), nil
+		}
+	}
+
+	if s.Type.Includes(TypeBoolean) {
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b, nil
+		}
+	}
+
+	if s.Type.IsZero() || s.Type.Includes(TypeString) {
+		return text, nil
+	}
+
+	return nil, fmt.Errorf("cannot decode %q as %s", text, s.Type)
+}
@@ -0,0 +1,128 @@
+package openapi_test
+
+import (
+	"encoding/json/v2"
+	"reflect"
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestSchema_EncodeXML(t *testing.T) {
+	t.Parallel()
+
+	s := &openapi.Schema{}
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"xml": {"namespace": "https://example.com/schema", "prefix": "ex"},
+		"properties": {
+			"id": {"type": "integer", "xml": {"attribute": true}},
+			"name": {"type": "string"},
+			"note": {"type": "string", "xml": {"nodeType": "cdata"}},
+			"tags": {"type": "array", "items": {"type": "string", "xml": {"name": "tag"}}},
+			"pets": {
+				"type": "array",
+				"xml": {"wrapped": true},
+				"items": {
+					"type": "object",
+					"properties": {
+						"kind": {"type": "string", "xml": {"nodeType": "attribute"}},
+						"name": {"type": "string", "xml": {"nodeType": "text"}}
+					}
+				}
+			},
+			"active": {"type": "boolean"}
+		}
+	}`), s); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	v := map[string]any{
+		"id":     openapi.Number("7"),
+		"name":   "Jane",
+		"note":   "a <b>",
+		"tags":   []any{"a", "b"},
+		"pets":   []any{map[string]any{"kind": "cat", "name": "Tom"}},
+		"active": true,
+		"extra":  "x",
+	}
+
+	data, err := s.EncodeXML("person", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<ex:person xmlns:ex="https://example.com/schema" id="7">` +
+		`<name>Jane</name><![CDATA[a <b>]]><tag>a</tag><tag>b</tag>` +
+		`<pets><pets kind="cat">Tom</pets></pets><active>true</active><extra>x</extra></ex:person>`
+	if string(data) != want {
+		t.Fatalf("want: %s\ngot:  %s", want, data)
+	}
+
+	got, err := s.DecodeXML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, v) {
+		t.Fatalf("want: %#v\ngot:  %#v", v, got)
+	}
+}
+
+func TestSchema_EncodeXML_Array(t *testing.T) {
+	t.Parallel()
+
+	// the root is always an element, even if the array is not wrapped
+	s := &openapi.Schema{
+		Type:  openapi.Types(openapi.TypeArray),
+		Items: &openapi.SchemaRef{Value: &openapi.Schema{Type: openapi.Types(openapi.TypeNumber, openapi.TypeNull)}},
+	}
+
+	v := []any{openapi.Number("1.5"), nil, openapi.Number("3")}
+	data, err := s.EncodeXML("numbers", v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `<numbers><numbers>1.5</numbers><numbers></numbers><numbers>3</numbers></numbers>`; string(data) != want {
+		t.Fatalf("want: %s\ngot:  %s", want, data)
+	}
+
+	got, err := s.DecodeXML(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, v) {
+		t.Fatalf("want: %#v\ngot:  %#v", v, got)
+	}
+}
+
+func TestSchema_DecodeXML_Error(t *testing.T) {
+	t.Parallel()
+
+	s := &openapi.Schema{
+		Type: openapi.Types(openapi.TypeObject),
+		Properties: openapi.SchemaRefs{
+			"age": {Value: &openapi.Schema{Type: openapi.Types(openapi.TypeInteger)}},
+		},
+	}
+
+	for _, tc := range []struct {
+		data string
+		err  string
+	}{
+		{``, `no root element`},
+		{`<person><age>old</age></person>`, `age: cannot decode "old" as integer`},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			if _, err := s.DecodeXML([]byte(tc.data)); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %v", tc.err, err)
+			}
+		})
+	}
+}
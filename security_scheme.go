@@ -113,5 +113,5 @@ func (l *loader) collectSecurityScheme(s *SecurityScheme, ref ref) {
 }
 
 func (l *loader) resolveSecuritySchemeRef(r *SecuritySchemeRef) error {
-	return resolveRef(r, l.securitySchemes, nil)
+	return resolveRef(l, r, l.securitySchemes, nil)
 }
@@ -1,6 +1,15 @@
 package openapi
 
-import "github.com/MarkRosemaker/errpath"
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/MarkRosemaker/errpath"
+)
 
 // Server is an object representing a Server.
 // ([Specification])
@@ -17,6 +26,9 @@ type Server struct {
 	Variables ServerVariables `json:"variables,omitempty" yaml:"variables,omitempty"`
 	// This object MAY be extended with Specification Extensions.
 	Extensions Extensions `json:",embed" yaml:",embed"`
+
+	// the compiled URL, see Match
+	pattern atomic.Value // *serverPattern
 }
 
 func (s *Server) Validate() error {
@@ -28,10 +40,196 @@ func (s *Server) Validate() error {
 		return &errpath.ErrField{Field: "variables", Err: err}
 	}
 
-	// // validate the default URL to see if the URL is well-formed
-	// if _, err := s.defaultURL(); err != nil {
-	// 	return fmt.Errorf("URL: %w", err)
-	// }
+	names := s.variableNames()
+	for _, name := range names {
+		if _, ok := s.Variables[name]; !ok {
+			return &errpath.ErrField{Field: "url", Err: &errpath.ErrInvalid[string]{
+				Value:   s.URL,
+				Message: fmt.Sprintf("variable {%s} is not declared", name),
+			}}
+		}
+	}
+
+	for name := range s.Variables.ByIndex() {
+		if !slices.Contains(names, name) {
+			return &errpath.ErrField{Field: "variables", Err: &errpath.ErrKey{
+				Key: name,
+				Err: fmt.Errorf("not used in url %q", s.URL),
+			}}
+		}
+	}
+
+	// validate the default URL to see if the URL is well-formed
+	if _, err := s.Expand(nil); err != nil {
+		return &errpath.ErrField{Field: "url", Err: &errpath.ErrInvalid[string]{Value: s.URL, Message: err.Error()}}
+	}
+
+	return validateExtensions(s.Extensions)
+}
+
+// variableNames returns the names of the variables in the URL, in order.
+func (s *Server) variableNames() []string {
+	var names []string
+	for _, m := range reTemplateExpressions.FindAllStringSubmatch(s.URL, -1) {
+		names = append(names, m[1])
+	}
+
+	return names
+}
+
+// Expand substitutes the variables in the URL with their default values or the given overrides.
+// An override must be one of the values of the variable's enum, if it has one.
+// The resulting URL may be relative, see Document.ResolveReference.
+func (s *Server) Expand(overrides map[string]string) (*url.URL, error) {
+	for name, value := range overrides {
+		v, ok := s.Variables[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable {%s}", name)
+		}
+
+		if len(v.Enum) > 0 && !slices.Contains(v.Enum, value) {
+			return nil, fmt.Errorf("value %q of variable {%s} must be one of %q", value, name, v.Enum)
+		}
+	}
+
+	var errs []string
+	expanded := reTemplateExpressions.ReplaceAllStringFunc(s.URL, func(expr string) string {
+		name := expr[1 : len(expr)-1]
+		if value, ok := overrides[name]; ok {
+			return value
+		}
+
+		if v, ok := s.Variables[name]; ok {
+			return v.Default
+		}
+
+		errs = append(errs, fmt.Sprintf("variable {%s} is not declared", name))
+		return expr
+	})
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+
+	return url.Parse(expanded)
+}
+
+// Match reports whether the URL is one of the server, i.e. it starts with the server URL with any allowed variable values.
+// It returns the values of the variables. If the server URL is relative, only the path of the URL is matched.
+// The scheme and host are matched case-insensitively, i.e. the values of variables in the host are in lower case.
+// To match a relative server URL against the location of the document, resolve the URL first, see Document.ResolveReference.
+//
+// The server URL is compiled on the first call and again whenever the URL changes.
+// Changes to the enums of the variables after the first call are not taken into account.
+func (s *Server) Match(u *url.URL) (map[string]string, bool) {
+	p := s.compiledPattern()
+	if p.re == nil {
+		return nil, false
+	}
+
+	target := u.EscapedPath()
+	switch {
+	case p.scheme:
+		target = strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + target
+	case p.authority:
+		target = "//" + strings.ToLower(u.Host) + target
+	}
+
+	m := p.re.FindStringSubmatch(target)
+	if m == nil {
+		return nil, false
+	}
+
+	vars := make(map[string]string, len(p.names))
+	for i, name := range p.names {
+		vars[name] = m[i+1]
+	}
+
+	return vars, true
+}
+
+// serverPattern is the compiled URL of a server, see Server.Match.
+type serverPattern struct {
+	// the URL the pattern was compiled from
+	url string
+	// matches URLs of the server, nil if the URL can't be compiled
+	re *regexp.Regexp
+	// the variable names, in order of the submatches of re
+	names []string
+	// whether the URL has a scheme and host, or only a host
+	scheme, authority bool
+}
+
+// compiledPattern returns the compiled URL of the server, compiling it if it changed.
+func (s *Server) compiledPattern() *serverPattern {
+	if p, ok := s.pattern.Load().(*serverPattern); ok && p.url == s.URL {
+		return p
+	}
+
+	p := &serverPattern{url: s.URL, names: s.variableNames()}
+	template := strings.TrimSuffix(s.URL, "/")
+
+	// the end of the scheme and host in the template, which are compared in lower case
+	hostEnd := 0
+	switch {
+	case strings.HasPrefix(template, "//"):
+		p.authority = true
+		hostEnd = authorityEnd(template, len("//"))
+	case strings.Contains(template, "://"):
+		p.scheme = true
+		hostEnd = authorityEnd(template, strings.Index(template, "://")+len("://"))
+	}
+
+	// quote returns the literal text of the template between two offsets, lowering the scheme and host
+	quote := func(from, to int) string {
+		text := template[from:to]
+		if from < hostEnd {
+			n := min(to, hostEnd) - from
+			text = strings.ToLower(text[:n]) + text[n:]
+		}
+
+		return regexp.QuoteMeta(text)
+	}
+
+	expr := &strings.Builder{}
+	expr.WriteByte('^')
+
+	prev := 0
+	for _, loc := range reTemplateExpressions.FindAllStringSubmatchIndex(template, -1) {
+		expr.WriteString(quote(prev, loc[0]))
+
+		if v := s.Variables[template[loc[2]:loc[3]]]; v != nil && len(v.Enum) > 0 {
+			quoted := make([]string, len(v.Enum))
+			for i, e := range v.Enum {
+				if loc[0] < hostEnd {
+					e = strings.ToLower(e)
+				}
+
+				quoted[i] = regexp.QuoteMeta(e)
+			}
+
+			expr.WriteString("(" + strings.Join(quoted, "|") + ")")
+		} else {
+			expr.WriteString("([^/?#]*)")
+		}
+
+		prev = loc[1]
+	}
+
+	expr.WriteString(quote(prev, len(template)))
+	expr.WriteString("(?:/.*)?$")
+
+	p.re, _ = regexp.Compile(expr.String())
+	s.pattern.Store(p)
+
+	return p
+}
+
+// authorityEnd returns the offset of the path in the template, given the offset of its authority.
+func authorityEnd(template string, start int) int {
+	if i := strings.IndexByte(template[start:], '/'); i >= 0 {
+		return start + i
+	}
 
-	return nil
+	return len(template)
 }
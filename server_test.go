@@ -1,6 +1,7 @@
 package openapi_test
 
 import (
+	"maps"
 	"testing"
 
 	"github.com/MarkRosemaker/openapi"
@@ -44,4 +45,161 @@ func TestServer_Validate(t *testing.T) {
 			t.Fatalf("got: %v, want: %v", err, want)
 		}
 	})
+
+	t.Run("undeclared variable", func(t *testing.T) {
+		s := openapi.Server{URL: "https://{env}.example.com"}
+		if err := s.Validate(); err == nil {
+			t.Fatal("expected error")
+		} else if want := `url ("https://{env}.example.com") is invalid: variable {env} is not declared`; err.Error() != want {
+			t.Fatalf("got: %v, want: %v", err, want)
+		}
+	})
+
+	t.Run("unused variable", func(t *testing.T) {
+		s := openapi.Server{URL: "https://example.com", Variables: openapi.ServerVariables{
+			"env": &openapi.ServerVariable{Default: "prod"},
+		}}
+		if err := s.Validate(); err == nil {
+			t.Fatal("expected error")
+		} else if want := `variables["env"]: not used in url "https://example.com"`; err.Error() != want {
+			t.Fatalf("got: %v, want: %v", err, want)
+		}
+	})
+}
+
+var templatedServer = &openapi.Server{
+	URL: "https://{env}.example.com:{port}/{version}",
+	Variables: openapi.ServerVariables{
+		"env":     &openapi.ServerVariable{Default: "api", Enum: []string{"api", "staging"}},
+		"port":    &openapi.ServerVariable{Default: "443"},
+		"version": &openapi.ServerVariable{Default: "v1"},
+	},
+}
+
+func TestServer_Expand(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		overrides map[string]string
+		want      string
+		err       string
+	}{
+		{nil, "https://api.example.com:443/v1", ""},
+		{map[string]string{"env": "staging", "port": "8443"}, "https://staging.example.com:8443/v1", ""},
+		{map[string]string{"env": "dev"}, "", `value "dev" of variable {env} must be one of ["api" "staging"]`},
+		{map[string]string{"region": "eu"}, "", "unknown variable {region}"},
+	} {
+		t.Run(tc.want+tc.err, func(t *testing.T) {
+			u, err := templatedServer.Expand(tc.overrides)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("want: %s, got: %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if u.String() != tc.want {
+				t.Fatalf("want: %s, got: %s", tc.want, u)
+			}
+		})
+	}
+}
+
+func TestServer_Match(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		server *openapi.Server
+		url    string
+		want   map[string]string
+	}{
+		{templatedServer, "https://staging.example.com:8443/v2/pets", map[string]string{"env": "staging", "port": "8443", "version": "v2"}},
+		{templatedServer, "https://api.example.com:443/v1", map[string]string{"env": "api", "port": "443", "version": "v1"}},
+		{templatedServer, "https://dev.example.com:443/v1", nil},
+		{templatedServer, "http://api.example.com:443/v1", nil},
+		{templatedServer, "https://api.example.com:443/v1beta", map[string]string{"env": "api", "port": "443", "version": "v1beta"}},
+		{&openapi.Server{URL: "/api"}, "/apis", nil},
+		{&openapi.Server{URL: "/api/"}, "https://example.com/api/pets", map[string]string{}},
+		{&openapi.Server{URL: "/api/"}, "/pets", nil},
+		{&openapi.Server{URL: "//example.com"}, "https://EXAMPLE.com/pets", map[string]string{}},
+		{&openapi.Server{URL: "https://API.example.com/V1"}, "https://api.example.com/V1/pets", map[string]string{}},
+		{&openapi.Server{URL: "HTTPS://api.example.com"}, "https://API.EXAMPLE.COM", map[string]string{}},
+		{&openapi.Server{URL: "https://api.example.com/V1"}, "https://api.example.com/v1", nil},
+		{&openapi.Server{URL: "https://{env}.example.com", Variables: openapi.ServerVariables{
+			"env": &openapi.ServerVariable{Default: "API", Enum: []string{"API", "Staging"}},
+		}}, "https://staging.example.com/pets", map[string]string{"env": "staging"}},
+	} {
+		t.Run(tc.server.URL+" "+tc.url, func(t *testing.T) {
+			vars, ok := tc.server.Match(mustParseURL(tc.url))
+			if ok != (tc.want != nil) {
+				t.Fatalf("want match: %t, got: %t", tc.want != nil, ok)
+			}
+
+			if !maps.Equal(vars, tc.want) {
+				t.Fatalf("want: %v, got: %v", tc.want, vars)
+			}
+		})
+	}
+}
+
+func TestServer_Match_URLChanged(t *testing.T) {
+	t.Parallel()
+
+	s := &openapi.Server{URL: "/v1"}
+	if _, ok := s.Match(mustParseURL("/v1/pets")); !ok {
+		t.Fatal("want match")
+	}
+
+	s.URL = "/v2"
+	if _, ok := s.Match(mustParseURL("/v1/pets")); ok {
+		t.Fatal("want no match after changing the URL")
+	}
+}
+
+func TestServers_Match(t *testing.T) {
+	t.Parallel()
+
+	ss := openapi.Servers{*templatedServer, {URL: "/"}}
+
+	s, vars, ok := ss.Match(mustParseURL("https://api.example.com:443/v1/pets"))
+	if !ok || s.URL != templatedServer.URL || vars["version"] != "v1" {
+		t.Fatalf("want first server, got: %v, %v, %t", s, vars, ok)
+	}
+
+	if s, _, ok = ss.Match(mustParseURL("https://other.example.com/pets")); !ok || s.URL != "/" {
+		t.Fatalf("want second server, got: %v, %t", s, ok)
+	}
+}
+
+func TestDocument_ResolveReference(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.LoadFromData([]byte(`{
+"openapi": "3.2.0",
+"$self": "https://example.com/specs/openapi.json",
+"info": {"title": "API", "version": "1.0"},
+"servers": [{"url": "../v1"}],
+"paths": {}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := doc.Servers[0].Expand(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := doc.ResolveReference(u).String(), "https://example.com/v1"; got != want {
+		t.Fatalf("want: %s, got: %s", want, got)
+	}
+
+	if got := (&openapi.Document{}).ResolveReference(u); got != u {
+		t.Fatalf("want the URL as is, got: %s", got)
+	}
 }
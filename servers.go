@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/MarkRosemaker/errpath"
+import (
+	"net/url"
+
+	"github.com/MarkRosemaker/errpath"
+)
 
 // Servers is a list of server objects.
 type Servers []Server
@@ -15,3 +19,14 @@ func (ss Servers) Validate() error {
 
 	return nil
 }
+
+// Match returns the first server that matches the URL and the values of its variables, see Server.Match.
+func (ss Servers) Match(u *url.URL) (*Server, map[string]string, bool) {
+	for i := range ss {
+		if vars, ok := ss[i].Match(u); ok {
+			return &ss[i], vars, true
+		}
+	}
+
+	return nil, nil, false
+}
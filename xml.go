@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+
+	"github.com/MarkRosemaker/errpath"
+)
+
+// A metadata object that allows for more fine-tuned XML model definitions.
+//
+// When using a Schema Object with XML, if no XML Object is present, the behavior is determined by the XML Object's default field values.
+//
+// ([Specification])
+//
+// [Specification]: https://spec.openapis.org/oas/v3.2.0.html#xml-object
+type XML struct {
+	// The kind of XML node this schema produces. The default is `none` if `type: array` is present and `element` otherwise.
+	NodeType XMLNodeType `json:"nodeType,omitempty" yaml:"nodeType,omitempty"`
+	// Sets the name of the element/attribute corresponding to the schema, replacing the name that was inferred.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// The IRI of the namespace definition. Value MUST be in the form of a non-relative IRI.
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// The prefix to be used for the name.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// Declares whether the property definition translates to an attribute instead of an element.
+	// Deprecated in OpenAPI 3.2 in favor of `nodeType: attribute`.
+	Attribute bool `json:"attribute,omitempty,omitzero" yaml:"attribute,omitempty"`
+	// MAY be used only for an array definition. Signifies whether the array is wrapped (for example, `<books><book/><book/></books>`) or unwrapped (`<book/><book/>`).
+	// Deprecated in OpenAPI 3.2 in favor of `nodeType: element`.
+	Wrapped bool `json:"wrapped,omitempty,omitzero" yaml:"wrapped,omitempty"`
+	// This object MAY be extended with Specification Extensions.
+	Extensions Extensions `json:",embed" yaml:"-"`
+}
+
+func (x *XML) Validate() error {
+	if x.NodeType != "" {
+		if err := x.NodeType.Validate(); err != nil {
+			return &errpath.ErrField{Field: "nodeType", Err: err}
+		}
+
+		if x.Attribute {
+			return errors.New("nodeType and attribute are mutually exclusive")
+		}
+
+		if x.Wrapped {
+			return errors.New("nodeType and wrapped are mutually exclusive")
+		}
+	}
+
+	if x.Namespace != "" {
+		if u, err := url.Parse(x.Namespace); err != nil {
+			return &errpath.ErrField{Field: "namespace", Err: err}
+		} else if !u.IsAbs() {
+			return &errpath.ErrField{Field: "namespace", Err: &errpath.ErrInvalid[string]{
+				Value:   x.Namespace,
+				Message: "must be an absolute URI",
+			}}
+		}
+	}
+
+	return validateExtensions(x.Extensions)
+}
+
+// XMLNodeType is the kind of XML node a schema produces.
+type XMLNodeType string
+
+const (
+	// The schema is represented by an element.
+	XMLNodeTypeElement XMLNodeType = "element"
+	// The schema is represented by an attribute.
+	XMLNodeTypeAttribute XMLNodeType = "attribute"
+	// The schema is represented by the text content of its parent element.
+	XMLNodeTypeText XMLNodeType = "text"
+	// The schema is represented by a CDATA section in its parent element.
+	XMLNodeTypeCDATA XMLNodeType = "cdata"
+	// The schema does not correspond to a node, its subschemas are included directly in the parent element.
+	XMLNodeTypeNone XMLNodeType = "none"
+)
+
+var allXMLNodeTypes = []XMLNodeType{
+	XMLNodeTypeElement,
+	XMLNodeTypeAttribute,
+	XMLNodeTypeText,
+	XMLNodeTypeCDATA,
+	XMLNodeTypeNone,
+}
+
+func (t XMLNodeType) Validate() error {
+	if slices.Contains(allXMLNodeTypes, t) {
+		return nil
+	}
+
+	return &errpath.ErrInvalid[XMLNodeType]{
+		Value: t,
+		Enum:  allXMLNodeTypes,
+	}
+}
@@ -0,0 +1,45 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/MarkRosemaker/openapi"
+)
+
+func TestXML_JSON(t *testing.T) {
+	t.Parallel()
+
+	testJSON(t, []byte(`{
+  "name": "animal",
+  "namespace": "https://example.com/schema/sample",
+  "prefix": "sample",
+  "wrapped": true
+}`), &openapi.XML{})
+
+	testJSON(t, []byte(`{
+  "nodeType": "attribute",
+  "name": "id",
+  "x-foo": "bar"
+}`), &openapi.XML{})
+}
+
+func TestXML_Validate_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		x   openapi.XML
+		err string
+	}{
+		{openapi.XML{NodeType: "comment"}, `nodeType ("comment") is invalid, must be one of: "element", "attribute", "text", "cdata", "none"`},
+		{openapi.XML{NodeType: openapi.XMLNodeTypeElement, Attribute: true}, `nodeType and attribute are mutually exclusive`},
+		{openapi.XML{NodeType: openapi.XMLNodeTypeElement, Wrapped: true}, `nodeType and wrapped are mutually exclusive`},
+		{openapi.XML{Namespace: "schema/sample"}, `namespace ("schema/sample") is invalid: must be an absolute URI`},
+		{openapi.XML{Namespace: ":"}, `namespace: parse ":": missing protocol scheme`},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			if err := tc.x.Validate(); err == nil || err.Error() != tc.err {
+				t.Fatalf("want: %s, got: %s", tc.err, err)
+			}
+		})
+	}
+}